@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/getoai/getoai-cli/internal/installer"
+	"github.com/getoai/getoai-cli/internal/tools"
+	"github.com/getoai/getoai-cli/internal/util"
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup <tool>",
+	Short: "Back up the data of a container or compose app",
+	Long: `Archive the data of an installed tool: the volumes and host directories
+of its container, or the directory and volumes of its compose app. The
+archive includes a manifest with the tool, its version and image digests.
+
+The service is stopped while its data is archived, and started again
+afterwards.
+
+Examples:
+  getoai backup open-webui
+  getoai backup dify -o dify-before-upgrade.tar.gz`,
+	Args: cobra.ExactArgs(1),
+	Run:  runBackup,
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <tool> <file>",
+	Short: "Restore the data of a container or compose app from a backup",
+	Long: `Replace the data of an installed tool with a backup made by 'getoai
+backup'. The backup must be of the same tool, installed the same way.
+If it was taken with another version or image, you are asked before
+anything is changed.
+
+The service is stopped while its data is replaced, and started again
+afterwards.
+
+Examples:
+  getoai restore open-webui open-webui-20250101-120000.tar.gz`,
+	Args: cobra.ExactArgs(2),
+	Run:  runRestore,
+}
+
+var (
+	backupOutput string
+	restoreYes   bool
+)
+
+func init() {
+	backupCmd.Flags().StringVarP(&backupOutput, "output", "o", "", "Archive file to write (default <tool>-<time>.tar.gz)")
+	restoreCmd.Flags().BoolVarP(&restoreYes, "yes", "y", false, "Replace the data without asking")
+	rootCmd.AddCommand(backupCmd)
+	rootCmd.AddCommand(restoreCmd)
+}
+
+func runBackup(cmd *cobra.Command, args []string) {
+	tool, ok := tools.Get(args[0])
+	if !ok {
+		printError(fmt.Sprintf("Unknown tool: %s", args[0]))
+		return
+	}
+	if !installer.CheckContainerRuntime() {
+		return
+	}
+	manifest, err := tool.BackupManifest()
+	if err != nil {
+		printError(err.Error())
+		return
+	}
+
+	file := backupOutput
+	if file == "" {
+		file = fmt.Sprintf("%s-%s.tar.gz", strings.ReplaceAll(tool.ID(), ":", "-"), time.Now().Format("20060102-150405"))
+	}
+
+	fmt.Printf("Backing up %s:\n", tool.ID())
+	for _, mount := range manifest.Mounts {
+		fmt.Printf("  %s\n", mount.Source)
+	}
+	spinner := util.NewSpinner(fmt.Sprintf("Archiving %s (the service is stopped meanwhile)...", tool.ID()))
+	spinner.Start()
+	if err := tool.Backup(file, manifest); err != nil {
+		spinner.Error(fmt.Sprintf("Backup failed: %v", err))
+		return
+	}
+	size := ""
+	if info, err := os.Stat(file); err == nil {
+		size = fmt.Sprintf(" (%s)", formatSize(uint64(info.Size())))
+	}
+	spinner.Success(fmt.Sprintf("Backed up %s to %s%s", tool.ID(), file, size))
+}
+
+func runRestore(cmd *cobra.Command, args []string) {
+	tool, ok := tools.Get(args[0])
+	if !ok {
+		printError(fmt.Sprintf("Unknown tool: %s", args[0]))
+		return
+	}
+	if !installer.CheckContainerRuntime() {
+		return
+	}
+	file := args[1]
+	manifest, err := installer.ReadBackupManifest(file)
+	if err != nil {
+		printError(err.Error())
+		return
+	}
+
+	fmt.Printf("Backup of %s from %s\n", manifest.Tool, manifest.CreatedAt.Local().Format("2006-01-02 15:04"))
+	if manifest.Version != "" {
+		fmt.Printf("  Version: %s\n", manifest.Version)
+	}
+	for _, image := range manifest.Images {
+		fmt.Printf("  Image:   %s\n", image.Image)
+	}
+
+	warnings, err := tool.CheckBackup(manifest)
+	if err != nil {
+		printError(fmt.Sprintf("Cannot restore: %v", err))
+		return
+	}
+	for _, w := range warnings {
+		fmt.Printf("\033[33m!\033[0m %s\n", w)
+	}
+	if !restoreYes {
+		fmt.Println()
+		if !util.Confirm(fmt.Sprintf("Replace the current data of %s with this backup?", tool.ID()), false) {
+			printInfo("Restore canceled")
+			return
+		}
+	}
+
+	spinner := util.NewSpinner(fmt.Sprintf("Restoring %s (the service is stopped meanwhile)...", tool.ID()))
+	spinner.Start()
+	if err := tool.Restore(file, manifest); err != nil {
+		spinner.Error(fmt.Sprintf("Restore failed: %v", err))
+		return
+	}
+	spinner.Success(fmt.Sprintf("Restored %s from %s", tool.ID(), file))
+	if tool.Status().Running {
+		waitReady(tool)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/getoai/getoai-cli/internal/config"
+	"github.com/getoai/getoai-cli/internal/installer"
 )
 
 var configCmd = &cobra.Command{
@@ -36,10 +37,18 @@ Available keys:
   npm_registry  - npm registry URL (e.g., https://registry.npmmirror.com)
   pypi_mirror   - PyPI mirror URL (e.g., https://pypi.tuna.tsinghua.edu.cn/simple)
   go_proxy      - Go module proxy (e.g., https://goproxy.cn,direct)
+  container_runtime - docker, podman or nerdctl (detected automatically if unset)
+  docker_mirror - image mirror put in front of image references, or
+                  registry=mirror pairs separated by commas; images are
+                  pulled from their registry when the mirror fails
+                  ("" to unset)
 
 Examples:
   getoai config set npm_registry https://registry.npmmirror.com
-  getoai config set go_proxy https://goproxy.cn,direct`,
+  getoai config set go_proxy https://goproxy.cn,direct
+  getoai config set container_runtime podman
+  getoai config set docker_mirror mirror.example.com
+  getoai config set docker_mirror docker.io=docker.1ms.run,ghcr.io=ghcr.nju.edu.cn`,
 	Args: cobra.ExactArgs(2),
 	Run:  runConfigSet,
 }
@@ -85,9 +94,16 @@ func runConfigShow(cmd *cobra.Command, args []string) {
 	if cfg.BinPath != "" {
 		fmt.Printf("bin_path:      %s\n", cfg.BinPath)
 	}
+	if cfg.ContainerRuntime != "" {
+		fmt.Printf("container_runtime: %s\n", cfg.ContainerRuntime)
+	}
+	if len(cfg.DockerMirror) > 0 {
+		fmt.Printf("docker_mirror: %s\n", installer.FormatDockerMirror(cfg.DockerMirror))
+	}
 
 	if cfg.HttpProxy == "" && cfg.HttpsProxy == "" && cfg.NpmRegistry == "" &&
-		cfg.PypiMirror == "" && cfg.GoProxy == "" && cfg.BinPath == "" {
+		cfg.PypiMirror == "" && cfg.GoProxy == "" && cfg.BinPath == "" && cfg.ContainerRuntime == "" &&
+		len(cfg.DockerMirror) == 0 {
 		fmt.Println("(No custom configuration set)")
 	}
 
@@ -117,9 +133,23 @@ func runConfigSet(cmd *cobra.Command, args []string) {
 		cfg.GoProxy = value
 	case "bin_path":
 		cfg.BinPath = value
+	case "container_runtime":
+		if _, err := installer.NewContainerRuntime(value); err != nil {
+			printError(err.Error())
+			return
+		}
+		cfg.ContainerRuntime = value
+	case "docker_mirror":
+		mirrors, err := installer.ParseDockerMirror(value)
+		if err != nil {
+			printError(err.Error())
+			return
+		}
+		cfg.DockerMirror = mirrors
+		value = installer.FormatDockerMirror(mirrors)
 	default:
 		printError(fmt.Sprintf("Unknown config key: %s", key))
-		fmt.Println("Available keys: http_proxy, https_proxy, npm_registry, pypi_mirror, go_proxy, bin_path")
+		fmt.Println("Available keys: http_proxy, https_proxy, npm_registry, pypi_mirror, go_proxy, bin_path, container_runtime, docker_mirror")
 		return
 	}
 
@@ -0,0 +1,223 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/getoai/getoai-cli/internal/installer"
+	"github.com/getoai/getoai-cli/internal/util"
+)
+
+var dockerCmd = &cobra.Command{
+	Use:   "docker",
+	Short: "Configure the Docker engine",
+	Long: `Configure the Docker engine getoai runs containers with.
+
+Examples:
+  getoai docker mirrors
+  getoai docker mirrors set https://docker.1ms.run https://docker.xuanyuan.me`,
+}
+
+var dockerMirrorsCmd = &cobra.Command{
+	Use:   "mirrors",
+	Short: "Show the registry mirrors of the Docker daemon",
+	Long: `Show the registry mirrors set in the Docker daemon's daemon.json, which
+Docker pulls Docker Hub images through.
+
+To pull through a mirror without changing the daemon, e.g. without root
+access, see 'getoai config set docker_mirror'.
+
+Examples:
+  getoai docker mirrors
+  getoai docker mirrors set https://docker.1ms.run`,
+	Args: cobra.NoArgs,
+	Run:  runDockerMirrors,
+}
+
+var dockerMirrorsSetCmd = &cobra.Command{
+	Use:   "set <url> [urls...]",
+	Short: "Add registry mirrors to the Docker daemon",
+	Long: `Add registry mirrors to the Docker daemon's daemon.json, ahead of the
+ones already set so Docker tries them first, keeping its other settings.
+Each mirror is checked first, and the ones that don't respond are left
+out. The current daemon.json is backed up next to it.
+
+On Linux the daemon is restarted after confirmation to apply the change,
+which stops running containers that have no restart policy. Docker
+Desktop has to be restarted from its menu.
+
+Examples:
+  getoai docker mirrors set https://docker.1ms.run https://docker.xuanyuan.me
+  getoai docker mirrors set --force https://mirror.internal:5000`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runDockerMirrorsSet,
+}
+
+var (
+	forceMirrors  bool
+	restartDocker bool
+)
+
+func init() {
+	dockerMirrorsSetCmd.Flags().BoolVar(&forceMirrors, "force", false, "Keep mirrors that don't respond")
+	dockerMirrorsSetCmd.Flags().BoolVarP(&restartDocker, "yes", "y", false, "Restart the Docker daemon without asking")
+	dockerMirrorsCmd.AddCommand(dockerMirrorsSetCmd)
+	dockerCmd.AddCommand(dockerMirrorsCmd)
+	rootCmd.AddCommand(dockerCmd)
+}
+
+// checkDockerEngine returns false, with a message, when the container
+// runtime isn't Docker, whose daemon.json these commands edit
+func checkDockerEngine() bool {
+	if name := installer.Runtime().Name(); name != "docker" {
+		printError(fmt.Sprintf("The container runtime is %s: registry mirrors are set in its registries.conf, not in Docker's daemon.json", name))
+		return false
+	}
+	return true
+}
+
+func runDockerMirrors(cmd *cobra.Command, args []string) {
+	if !checkDockerEngine() {
+		return
+	}
+	path := installer.DaemonConfigPath()
+	data, err := installer.ReadDaemonConfig(path)
+	if err != nil {
+		printError(err.Error())
+		return
+	}
+	mirrors, err := installer.RegistryMirrors(data)
+	if err != nil {
+		printError(fmt.Sprintf("%s: %v", path, err))
+		return
+	}
+	if len(mirrors) == 0 {
+		printInfo(fmt.Sprintf("No registry mirrors set in %s", path))
+		fmt.Println("  Set some with: getoai docker mirrors set <url...>")
+		return
+	}
+	fmt.Printf("Registry mirrors in %s:\n", path)
+	for _, m := range mirrors {
+		fmt.Printf("  %s\n", m)
+	}
+}
+
+func runDockerMirrorsSet(cmd *cobra.Command, args []string) {
+	setDockerMirrors(args)
+}
+
+// setDockerMirrors adds the mirrors to daemon.json and offers to restart
+// Docker. It reports whether daemon.json was changed.
+func setDockerMirrors(args []string) bool {
+	if !checkDockerEngine() {
+		return false
+	}
+
+	var mirrors []string
+	seen := make(map[string]bool)
+	for _, arg := range args {
+		mirror, err := installer.NormalizeMirror(arg)
+		if err != nil {
+			printError(err.Error())
+			return false
+		}
+		if !seen[mirror] {
+			seen[mirror] = true
+			mirrors = append(mirrors, mirror)
+		}
+	}
+
+	fmt.Println("Checking mirrors...")
+	var working []string
+	for _, mirror := range mirrors {
+		if err := installer.CheckMirror(mirror); err != nil {
+			fmt.Printf("  \033[31m✗\033[0m %s: %v\n", mirror, err)
+			if forceMirrors {
+				working = append(working, mirror)
+			}
+			continue
+		}
+		fmt.Printf("  \033[32m✓\033[0m %s\n", mirror)
+		working = append(working, mirror)
+	}
+	if len(working) == 0 {
+		printError("None of the mirrors responded, daemon.json was not changed (use --force to set them anyway)")
+		return false
+	}
+
+	path := installer.DaemonConfigPath()
+	data, err := installer.ReadDaemonConfig(path)
+	if err != nil {
+		printError(err.Error())
+		return false
+	}
+	updated, err := installer.MergeRegistryMirrors(data, working)
+	if err != nil {
+		// Don't overwrite a file the daemon may fail on already
+		printError(fmt.Sprintf("%s: %v, fix it first", path, err))
+		return false
+	}
+
+	backup, err := installer.WriteDaemonConfig(path, updated)
+	if err != nil {
+		printError(err.Error())
+		return false
+	}
+	printSuccess(fmt.Sprintf("Registry mirrors set in %s", path))
+	merged, _ := installer.RegistryMirrors(updated)
+	for _, m := range merged {
+		fmt.Printf("  %s\n", m)
+	}
+	if backup != "" {
+		fmt.Printf("  Previous version saved as %s\n", backup)
+	}
+
+	if runtime.GOOS != "linux" {
+		printInfo("Restart Docker Desktop to apply the change")
+		return true
+	}
+	if !restartDocker && !util.Confirm("Restart the Docker daemon now to apply it? Running containers without a restart policy stop.", false) {
+		printInfo("Apply it later with: " + installer.RestartDockerCommand())
+		return true
+	}
+	// No spinner: sudo may ask for a password
+	fmt.Println("Restarting Docker...")
+	if err := installer.RestartDocker(); err != nil {
+		printError(fmt.Sprintf("Failed to restart Docker: %v", err))
+		if backup != "" {
+			fmt.Printf("  If the daemon doesn't start, restore the previous config: sudo cp %s %s\n", backup, path)
+		}
+		return true
+	}
+	printSuccess("Docker restarted with the new mirrors")
+	return true
+}
+
+// offerMirrorSetup offers to add the suggested registry mirrors after an
+// install or update failed to pull a Docker Hub image, unless they are set
+// already
+func offerMirrorSetup(err error) {
+	var pullErr *installer.PullError
+	if !errors.As(err, &pullErr) || !pullErr.FromDockerHub() || installer.Runtime().Name() != "docker" {
+		return
+	}
+	data, _ := installer.ReadDaemonConfig(installer.DaemonConfigPath())
+	current, _ := installer.RegistryMirrors(data)
+	missing := slices.DeleteFunc(slices.Clone(installer.SuggestedMirrors), func(m string) bool {
+		return slices.Contains(current, m)
+	})
+	if len(missing) == 0 {
+		return
+	}
+	if !util.Confirm(fmt.Sprintf("Add the registry mirrors %s to Docker now?", strings.Join(missing, " ")), false) {
+		return
+	}
+	if setDockerMirrors(missing) {
+		fmt.Println("  Run the command again once Docker uses the mirrors")
+	}
+}
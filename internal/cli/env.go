@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/getoai/getoai-cli/internal/installer"
+	"github.com/getoai/getoai-cli/internal/tools"
+)
+
+var envCmd = &cobra.Command{
+	Use:   "env",
+	Short: "View and edit the .env settings of compose apps",
+	Long: `View and edit the .env file of a tool installed as a docker compose app
+(dify, librechat, ragflow, ...).
+
+Secrets such as SECRET_KEY are generated when the app is installed. Changes
+take effect once the containers are recreated: pass --restart to 'env set',
+or run 'getoai start <tool>' later.
+
+Examples:
+  getoai env list dify
+  getoai env get dify EXPOSE_NGINX_PORT
+  getoai env set dify EXPOSE_NGINX_PORT=8081 --restart`,
+}
+
+var envListCmd = &cobra.Command{
+	Use:   "list <tool>",
+	Short: "List the settings in a compose app's .env",
+	Args:  cobra.ExactArgs(1),
+	Run:   runEnvList,
+}
+
+var envGetCmd = &cobra.Command{
+	Use:   "get <tool> <KEY>",
+	Short: "Print one setting from a compose app's .env",
+	Args:  cobra.ExactArgs(2),
+	Run:   runEnvGet,
+}
+
+var envSetCmd = &cobra.Command{
+	Use:   "set <tool> <KEY=VALUE>...",
+	Short: "Change settings in a compose app's .env",
+	Args:  cobra.MinimumNArgs(2),
+	Run:   runEnvSet,
+}
+
+var (
+	envShowSecrets bool
+	envRestart     bool
+)
+
+func init() {
+	envListCmd.Flags().BoolVar(&envShowSecrets, "show-secrets", false, "Show secret values instead of masking them")
+	envSetCmd.Flags().BoolVar(&envRestart, "restart", false, "Recreate the app's containers to apply the change")
+	envCmd.AddCommand(envListCmd)
+	envCmd.AddCommand(envGetCmd)
+	envCmd.AddCommand(envSetCmd)
+	rootCmd.AddCommand(envCmd)
+}
+
+// composeEnvFile returns the tool and its .env file, printing the problem
+// if there is none
+func composeEnvFile(name string) (*tools.Tool, string, bool) {
+	tool, ok := tools.Get(name)
+	if !ok {
+		printError(fmt.Sprintf("Unknown tool: %s", name))
+		return nil, "", false
+	}
+	envFile, err := tool.EnvFile()
+	if err != nil {
+		printError(err.Error())
+		return nil, "", false
+	}
+	return tool, envFile, true
+}
+
+func runEnvList(cmd *cobra.Command, args []string) {
+	tool, envFile, ok := composeEnvFile(args[0])
+	if !ok {
+		return
+	}
+	env, err := installer.ReadEnvFile(envFile)
+	if err != nil {
+		printError(fmt.Sprintf("Failed to read %s: %v", envFile, err))
+		return
+	}
+	if len(env) == 0 {
+		printInfo(fmt.Sprintf("No settings in %s", envFile))
+		return
+	}
+
+	fmt.Printf("# %s\n", envFile)
+	for _, key := range sortedEnvKeys(env) {
+		value := env[key]
+		if !envShowSecrets && value != "" && tool.IsSecretEnv(key) {
+			value = "********"
+		}
+		fmt.Printf("%s=%s\n", key, value)
+	}
+}
+
+func runEnvGet(cmd *cobra.Command, args []string) {
+	_, envFile, ok := composeEnvFile(args[0])
+	if !ok {
+		return
+	}
+	env, err := installer.ReadEnvFile(envFile)
+	if err != nil {
+		printError(fmt.Sprintf("Failed to read %s: %v", envFile, err))
+		return
+	}
+	value, ok := env[args[1]]
+	if !ok {
+		printError(fmt.Sprintf("%s is not set in %s", args[1], envFile))
+		return
+	}
+	fmt.Println(value)
+}
+
+func runEnvSet(cmd *cobra.Command, args []string) {
+	// Validate every assignment before touching the file
+	type assignment struct{ key, value string }
+	var assignments []assignment
+	for _, arg := range args[1:] {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || !installer.ValidEnvKey(key) {
+			printError(fmt.Sprintf("Invalid setting %q, expected KEY=VALUE", arg))
+			return
+		}
+		assignments = append(assignments, assignment{key, value})
+	}
+
+	tool, envFile, ok := composeEnvFile(args[0])
+	if !ok {
+		return
+	}
+	for _, a := range assignments {
+		if err := installer.SetEnvValue(envFile, a.key, installer.QuoteEnvValue(a.value)); err != nil {
+			printError(fmt.Sprintf("Failed to update %s: %v", envFile, err))
+			return
+		}
+		printSuccess(fmt.Sprintf("Set %s in %s", a.key, envFile))
+	}
+
+	if !envRestart {
+		fmt.Printf("  Run 'getoai start %s' to apply the change\n", tool.ID())
+		return
+	}
+	fmt.Printf("Recreating %s...\n", tool.ID())
+	if err := tool.ApplyEnv(); err != nil {
+		printError(fmt.Sprintf("Failed to restart %s: %v", tool.ID(), err))
+		return
+	}
+	printSuccess(fmt.Sprintf("%s restarted with the new settings", tool.ID()))
+	waitReady(tool)
+}
+
+func sortedEnvKeys(env map[string]string) []string {
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
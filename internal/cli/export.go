@@ -0,0 +1,207 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/getoai/getoai-cli/internal/installer"
+	"github.com/getoai/getoai-cli/internal/tools"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export installed tools as deployment files",
+	Long: `Export the settings getoai installed tools with, so the same setup can
+be deployed elsewhere and kept under version control.
+
+Examples:
+  getoai export compose > docker-compose.yml
+  getoai export compose open-webui lobechat -o docker-compose.yml
+  getoai export k8s open-webui | kubectl apply -f -`,
+}
+
+var exportComposeCmd = &cobra.Command{
+	Use:   "compose [tools...]",
+	Short: "Export single-container tools as a docker compose file",
+	Long: `Write a docker compose file with a service for each tool that runs as a
+single container: its image, ports, environment, volumes and network, as
+recorded when it was installed. Without arguments, all installed container
+tools are exported, named instances included. An instance becomes the
+service <tool>-<instance>.
+
+Volumes and the getoai network that already exist are marked external,
+so the file uses the data of the installed tools and 'docker compose
+down -v' doesn't delete it. With --standalone compose creates and owns
+them, e.g. to deploy the file on another machine.
+
+Tools installed as compose apps (dify, librechat, ...) already have a
+compose file in ~/.getoai/tools/<tool>.
+
+Examples:
+  getoai export compose > docker-compose.yml
+  getoai export compose open-webui lobechat -o docker-compose.yml
+  getoai export compose --standalone open-webui -o docker-compose.yml`,
+	Run: runExportCompose,
+}
+
+var exportK8sCmd = &cobra.Command{
+	Use:   "k8s <tool> [tools...]",
+	Short: "Export tools as Kubernetes manifests",
+	Long: `Write Kubernetes manifests for tools that run as a container, such as
+open-webui, lobechat, flowise, langflow, one-api or new-api: a Deployment
+with the tool's image, env, resource requests and readiness probe, a
+Service for its ports, a PersistentVolumeClaim for each volume, and a
+Secret holding secret env values.
+
+Installed tools are exported with the settings they were installed with,
+others with the registry defaults.
+
+Examples:
+  getoai export k8s open-webui | kubectl apply -f -
+  getoai export k8s one-api lobechat -o getoai.yaml`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runExportK8s,
+}
+
+var (
+	exportOutput     string
+	exportStandalone bool
+)
+
+func init() {
+	exportCmd.PersistentFlags().StringVarP(&exportOutput, "output", "o", "", "Write to a file instead of stdout")
+	exportComposeCmd.Flags().BoolVar(&exportStandalone, "standalone", false, "Let compose create the volumes and network instead of using existing ones")
+	exportCmd.AddCommand(exportComposeCmd)
+	exportCmd.AddCommand(exportK8sCmd)
+	rootCmd.AddCommand(exportCmd)
+}
+
+// exportError reports a problem on stderr, keeping stdout for the
+// exported file
+func exportError(msg string) {
+	fmt.Fprintf(os.Stderr, "\033[31m✗\033[0m %s\n", msg)
+}
+
+// exportTools returns the tools to export: the named ones, or every
+// installed single-container tool and instance
+func exportTools(names []string) ([]*tools.Tool, bool) {
+	if len(names) == 0 {
+		var installed []*tools.Tool
+		for _, tool := range append(tools.List(), tools.Instances()...) {
+			if tool.ServiceKind() == tools.ServiceContainer {
+				installed = append(installed, tool)
+			}
+		}
+		sort.Slice(installed, func(i, j int) bool {
+			return installed[i].ID() < installed[j].ID()
+		})
+		if len(installed) == 0 {
+			exportError("No container tools installed")
+		}
+		return installed, len(installed) > 0
+	}
+
+	var selected []*tools.Tool
+	for _, name := range names {
+		tool, ok := tools.Get(name)
+		if !ok {
+			exportError(fmt.Sprintf("Unknown tool: %s", name))
+			return nil, false
+		}
+		if tool.ServiceKind() == tools.ServiceCompose {
+			exportError(fmt.Sprintf("%s is a compose app, its compose file is in %s", name, tool.GetComposeInstallDir()))
+			return nil, false
+		}
+		selected = append(selected, tool)
+	}
+	return selected, true
+}
+
+// serviceName returns the compose service of the install, e.g.
+// open-webui-staging for open-webui:staging
+func serviceName(tool *tools.Tool) string {
+	return strings.ReplaceAll(tool.ID(), ":", "-")
+}
+
+// writeExport writes the exported file to --output or stdout
+func writeExport(content string) {
+	if exportOutput == "" {
+		fmt.Print(content)
+		return
+	}
+	if err := os.WriteFile(exportOutput, []byte(content), 0600); err != nil {
+		exportError(fmt.Sprintf("Failed to write %s: %v", exportOutput, err))
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\033[32m✓\033[0m Wrote %s\n", exportOutput)
+}
+
+// exportNotes points out what the exported file doesn't carry over: host
+// directories, and secrets written in plain text
+func exportNotes(tool *tools.Tool, spec installer.ContainerSpec) {
+	for _, v := range spec.Volumes {
+		if source := installer.VolumeSource(v); source != "" && !installer.IsNamedVolume(source) {
+			fmt.Fprintf(os.Stderr, "\033[33m!\033[0m %s mounts the host path %s, copy it along with the file\n", tool.ID(), source)
+		}
+	}
+	for key, value := range spec.Env {
+		if value != "" && tool.IsSecretEnv(key) {
+			fmt.Fprintf(os.Stderr, "\033[33m!\033[0m %s sets %s in plain text, keep the file private\n", tool.ID(), key)
+		}
+	}
+}
+
+func runExportCompose(cmd *cobra.Command, args []string) {
+	selected, ok := exportTools(args)
+	if !ok {
+		return
+	}
+
+	var services []installer.ComposeService
+	for _, tool := range selected {
+		spec, err := tool.ExportSpec()
+		if err != nil {
+			exportError(err.Error())
+			return
+		}
+		exportNotes(tool, spec)
+		services = append(services, installer.ComposeService{Name: serviceName(tool), Spec: spec})
+	}
+	var ext installer.ComposeExternal
+	if !exportStandalone {
+		ext = installer.ExistingExternal(services)
+	}
+	writeExport(installer.ComposeYAML(services, ext))
+}
+
+func runExportK8s(cmd *cobra.Command, args []string) {
+	var docs []string
+	for _, name := range args {
+		tool, ok := tools.Get(name)
+		if !ok {
+			exportError(fmt.Sprintf("Unknown tool: %s", name))
+			return
+		}
+		app, err := tool.K8sApp()
+		if err != nil {
+			exportError(err.Error())
+			return
+		}
+		for _, v := range app.Spec.Volumes {
+			if source := installer.VolumeSource(v); source != "" && !installer.IsNamedVolume(source) {
+				fmt.Fprintf(os.Stderr, "\033[33m!\033[0m %s mounts the host path %s, it becomes an empty volume claim\n", name, source)
+			}
+		}
+		for key, value := range app.Spec.Env {
+			if strings.Contains(value, "host.docker.internal") {
+				fmt.Fprintf(os.Stderr, "\033[33m!\033[0m %s sets %s=%s, point it at a service in the cluster\n", name, key, value)
+			}
+		}
+		docs = append(docs, installer.K8sManifests(app))
+	}
+	writeExport(strings.Join(docs, "---\n"))
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -50,5 +51,32 @@ func runInfo(cmd *cobra.Command, args []string) {
 		}
 		fmt.Println()
 	}
+
+	if len(tool.Requires) > 0 {
+		fmt.Printf("Depends on:  %s\n", strings.Join(tool.Requires, ", "))
+	}
+	if len(tool.Recommends) > 0 {
+		fmt.Printf("Recommends:  %s\n", strings.Join(tool.Recommends, ", "))
+	}
+	if len(tool.Provides) > 0 {
+		fmt.Printf("Provides:    %s\n", strings.Join(tool.Provides, ", "))
+	}
+	if len(tool.Conflicts) > 0 {
+		fmt.Printf("Conflicts:   %s\n", strings.Join(tool.Conflicts, ", "))
+	}
+
+	if tool.Resources.HasRequirements() {
+		fmt.Printf("Resources:   %s\n", tool.Resources)
+	}
+
+	for _, m := range methods {
+		for _, req := range tool.RuntimeRequirements(m) {
+			status := "\033[32m✓\033[0m"
+			if !req.Satisfied() {
+				status = "\033[31m✗\033[0m"
+			}
+			fmt.Printf("Requires:    %s %s (%s) %s\n", req.Runtime, req.Constraint, m, status)
+		}
+	}
 	fmt.Println()
 }
@@ -23,21 +23,68 @@ var installCmd = &cobra.Command{
 When multiple installation methods are available, you'll be prompted
 to choose your preferred method. Use --method to skip the prompt.
 
+Tools required by the requested tools are installed first, in dependency
+order. Use --with-recommended to also install recommended companions.
+
+A stack (see 'getoai stack list') installs a curated set of tools that
+are wired to work together.
+
+Containers share the getoai network. UIs that run as a single container
+(open-webui, lobechat, anythingllm) get the URLs of the installed local
+model servers, such as ollama; installing a server later offers to
+recreate them with it. Compose apps such as dify and librechat aren't
+wired: add the server in their settings, e.g.
+http://host.docker.internal:11434 for ollama.
+
 Examples:
   getoai install ollama
   getoai install claude-code aider
   getoai install ollama --method brew
-  getoai install ollama --method docker`,
+  getoai install ollama --method docker
+  getoai install ragflow --force         # skip resource preflight
+  getoai install open-webui --with-recommended
+  getoai install stack/local-chat
+  getoai install open-webui --port 3001:8080 --env WEBUI_AUTH=False
+  getoai install lobechat --name my-chat --volume ./data:/app/data
+  getoai install flowise --auto-port
+  getoai install open-webui --instance staging --env OLLAMA_BASE_URL=http://gpu-box:11434
+
+Container options (--port, --env, --volume, --name) override the defaults
+of tools that run as a docker container. They are saved in the install
+receipt and reused when the tool is updated or its container recreated.
+
+Host ports are checked before containers are started. When one is taken,
+getoai offers the next free port; --auto-port picks it without asking.
+
+--instance installs another copy of a docker tool next to the default one,
+with its own container, volumes, compose directory and ports. Address it
+as <tool>:<instance> in other commands, e.g. 'getoai logs open-webui:staging'.`,
 	Args: cobra.MinimumNArgs(1),
 	Run:  runInstall,
 }
 
 var installMethod string
 var skipDepsCheck bool
+var forceInstall bool
+var installWithRecommended bool
+var installName string
+var installPorts []string
+var installEnv []string
+var installVolumes []string
+var autoPort bool
+var installInstance string
 
 func init() {
 	installCmd.Flags().StringVarP(&installMethod, "method", "m", "", "Installation method (brew, npm, pip, script, go, docker)")
 	installCmd.Flags().BoolVar(&skipDepsCheck, "skip-deps", false, "Skip dependency check")
+	installCmd.Flags().BoolVar(&forceInstall, "force", false, "Install despite insufficient resources or conflicting tools")
+	installCmd.Flags().BoolVar(&installWithRecommended, "with-recommended", false, "Also install recommended companion tools")
+	installCmd.Flags().StringVar(&installName, "name", "", "Container name")
+	installCmd.Flags().StringArrayVarP(&installPorts, "port", "p", nil, "Port mapping host:container, or a host port for the main port (repeatable)")
+	installCmd.Flags().StringArrayVarP(&installEnv, "env", "e", nil, "Environment variable KEY=VALUE (repeatable)")
+	installCmd.Flags().StringArrayVarP(&installVolumes, "volume", "v", nil, "Volume mapping source:target (repeatable)")
+	installCmd.Flags().BoolVar(&autoPort, "auto-port", false, "Use the next free port when a port is already in use, without asking")
+	installCmd.Flags().StringVar(&installInstance, "instance", "", "Install a named instance of a docker tool, next to the default one")
 }
 
 func runInstall(cmd *cobra.Command, args []string) {
@@ -47,9 +94,252 @@ func runInstall(cmd *cobra.Command, args []string) {
 		cfg.ApplyEnv()
 	}
 
+	// Report unknown tools up front, resolve dependencies for the rest.
+	// Stacks expand to their tools.
+	if installInstance != "" {
+		if len(args) != 1 || strings.Contains(args[0], ":") || strings.HasPrefix(args[0], tools.StackPrefix) {
+			printError("--instance can only be used when installing a single tool")
+			return
+		}
+		args = []string{args[0] + ":" + installInstance}
+	}
+
+	// Named instances are planned as their tool, and installed by ID
+	var names []string
+	var installStacks []*tools.Stack
+	instances := make(map[string]string)
 	for _, toolName := range args {
-		installTool(toolName)
+		if strings.HasPrefix(toolName, tools.StackPrefix) {
+			stack, ok := tools.GetStack(toolName)
+			if !ok {
+				printError(fmt.Sprintf("Unknown stack: %s", toolName))
+				fmt.Println("  Run 'getoai stack list' to see the available stacks")
+				fmt.Println()
+				continue
+			}
+			installStacks = append(installStacks, stack)
+			names = append(names, stack.ToolNames()...)
+			continue
+		}
+		base, instance := tools.SplitInstance(toolName)
+		if _, ok := tools.Get(base); !ok {
+			printError(fmt.Sprintf("Unknown tool: %s", base))
+			suggestSimilar(base)
+			fmt.Println()
+			continue
+		}
+		if instance != "" {
+			if err := tools.ValidInstance(instance); err != nil {
+				printError(err.Error())
+				continue
+			}
+			instances[base] = toolName
+		}
+		names = append(names, base)
+	}
+	if len(names) == 0 {
+		return
+	}
+
+	opts, err := containerOptions()
+	if err != nil {
+		printError(err.Error())
+		return
+	}
+	if !opts.IsZero() && len(names) > 1 {
+		printError("--port, --env, --volume and --name can only be used when installing a single tool")
+		return
+	}
+	// Dependencies and recommendations pick their own method
+	opts.Method = installer.InstallMethod(installMethod)
+
+	plan, err := tools.ResolveInstallPlan(names, opts.Method, installWithRecommended)
+	if err != nil {
+		printError(fmt.Sprintf("Cannot resolve dependencies: %v", err))
+		return
+	}
+
+	if !confirmInstallPlan(plan) || !checkConflicts(plan) {
+		printInfo("Install canceled")
+		return
+	}
+
+	failed := make(map[string]bool)
+	for _, step := range plan {
+		if dep := failedDependency(step.Tool, failed); dep != "" {
+			printError(fmt.Sprintf("Skipping %s: required tool %s was not installed", step.Tool.Name, dep))
+			failed[step.Tool.Name] = true
+			fmt.Println()
+			continue
+		}
+		// The method and container options only apply to the requested tool
+		toolOpts := tools.InstallOptions{}
+		if step.Requested {
+			toolOpts = opts
+		}
+		stackEnv := stackMemberEnv(installStacks, step.Tool.Name)
+		if step.Tool.IsInstalled() && step.Tool.ServiceKind() == tools.ServiceContainer && len(stackEnv) > 0 {
+			// Point the existing container at the rest of the stack
+			if _, err := step.Tool.ConnectBackends(stackEnv, nil); err != nil {
+				printError(fmt.Sprintf("Failed to connect %s to the stack: %v", step.Tool.Name, err))
+			}
+		} else {
+			toolOpts.BackendEnv = stackEnv
+		}
+		id := step.Tool.Name
+		if full, ok := instances[id]; ok && step.Requested {
+			id = full
+		}
+		if !installTool(id, toolOpts) {
+			failed[step.Tool.Name] = true
+		} else if !step.Requested {
+			// Later steps may install with the runtime it brought
+			platform.Refresh()
+		}
+		fmt.Println()
+	}
+
+	for _, stack := range installStacks {
+		showStackNotes(stack, failed)
+	}
+
+	if !installWithRecommended {
+		showRecommendations(plan)
+	}
+}
+
+// stackMemberEnv returns the env vars a tool gets as a member of the stacks
+func stackMemberEnv(stacks []*tools.Stack, name string) map[string]string {
+	for _, stack := range stacks {
+		if member, ok := stack.Member(name); ok && len(member.Env) > 0 {
+			return member.Env
+		}
+	}
+	return nil
+}
+
+// showStackNotes reports an installed stack and the steps left to the user
+func showStackNotes(stack *tools.Stack, failed map[string]bool) {
+	for _, name := range stack.ToolNames() {
+		if failed[name] {
+			printError(fmt.Sprintf("Stack %s is incomplete: %s was not installed", stack.Name, name))
+			return
+		}
+	}
+	printSuccess(fmt.Sprintf("Stack %s installed", stack.Name))
+	if stack.Notes != "" {
 		fmt.Println()
+		for _, line := range strings.Split(stack.Notes, "\n") {
+			fmt.Printf("  %s\n", line)
+		}
+	}
+	fmt.Println()
+	fmt.Printf("  getoai stack status %s\n", stack.Name)
+	fmt.Println()
+}
+
+// containerOptions builds the container overrides from the install flags
+func containerOptions() (tools.InstallOptions, error) {
+	opts := tools.InstallOptions{
+		Name:    installName,
+		Ports:   installPorts,
+		Volumes: installVolumes,
+	}
+	for _, kv := range installEnv {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok || key == "" {
+			return opts, fmt.Errorf("invalid --env %q, expected KEY=VALUE", kv)
+		}
+		if opts.Env == nil {
+			opts.Env = make(map[string]string)
+		}
+		opts.Env[key] = value
+	}
+	return opts, nil
+}
+
+// confirmInstallPlan shows the plan when dependencies were added to the
+// requested tools and asks for confirmation
+func confirmInstallPlan(plan []tools.PlanStep) bool {
+	extra := false
+	for _, step := range plan {
+		if !step.Requested && !step.Tool.IsInstalled() {
+			extra = true
+			break
+		}
+	}
+	if !extra {
+		return true
+	}
+
+	fmt.Println("Installation plan:")
+	for i, step := range plan {
+		note := ""
+		if step.Reason == "requires" {
+			note = fmt.Sprintf(" (required by %s)", step.RequiredBy)
+		} else if step.Reason == "recommends" {
+			note = fmt.Sprintf(" (recommended by %s)", step.RequiredBy)
+		}
+		if step.Tool.IsInstalled() {
+			note += " \033[32m✓ installed\033[0m"
+		}
+		fmt.Printf("  %d. %s%s\n", i+1, step.Tool.Name, note)
+	}
+	fmt.Println()
+	return util.Confirm("Proceed?", true)
+}
+
+// checkConflicts warns about conflicting tools in the plan or on the system
+// and asks whether to continue, unless --force is set
+func checkConflicts(plan []tools.PlanStep) bool {
+	conflicts := tools.FindConflicts(plan)
+	if len(conflicts) == 0 {
+		return true
+	}
+
+	fmt.Printf("\033[33m!\033[0m Conflicting tools:\n")
+	for _, c := range conflicts {
+		where := "also being installed"
+		if c.Installed {
+			where = "already installed"
+		}
+		reason := ""
+		if c.Reason != c.With {
+			reason = fmt.Sprintf(", both provide %s", c.Reason)
+		}
+		fmt.Printf("  %s conflicts with %s (%s%s)\n", c.Tool, c.With, where, reason)
+	}
+
+	if forceInstall {
+		printInfo("Continuing despite conflicts (--force)")
+		return true
+	}
+	return util.Confirm("Install anyway?", false)
+}
+
+// failedDependency returns the first required tool of t that failed to install
+func failedDependency(t *tools.Tool, failed map[string]bool) string {
+	for _, dep := range t.Requires {
+		if failed[dep] {
+			return dep
+		}
+	}
+	return ""
+}
+
+// showRecommendations lists recommended tools that are not installed yet
+func showRecommendations(plan []tools.PlanStep) {
+	for _, step := range plan {
+		var missing []string
+		for _, name := range step.Tool.Recommends {
+			if rec, ok := tools.Get(name); ok && !rec.IsInstalled() {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) > 0 {
+			printInfo(fmt.Sprintf("%s works best with: %s", step.Tool.Name, strings.Join(missing, ", ")))
+			fmt.Printf("  Install with: getoai install %s --with-recommended\n", step.Tool.Name)
+		}
 	}
 }
 
@@ -82,17 +372,21 @@ func promptMethodSelection(toolName string, availableMethods []installer.Install
 	return availableMethods[choice], nil
 }
 
-func installTool(name string) {
+// installTool installs a single tool and reports whether it is installed afterwards
+func installTool(name string, opts tools.InstallOptions) bool {
 	tool, ok := tools.Get(name)
 	if !ok {
 		printError(fmt.Sprintf("Unknown tool: %s", name))
 		suggestSimilar(name)
-		return
+		return false
 	}
 
 	if tool.IsInstalled() {
-		printInfo(fmt.Sprintf("%s is already installed (version: %s)", name, tool.GetVersion()))
-		return
+		if opts.IsZero() {
+			printInfo(fmt.Sprintf("%s is already installed (version: %s)", name, tool.GetVersion()))
+			return true
+		}
+		printInfo(fmt.Sprintf("%s is already installed, recreating its container with the new options", name))
 	}
 
 	availableMethods := tool.GetAvailableMethods()
@@ -105,7 +399,7 @@ func installTool(name string) {
 				// Install dependencies
 				for _, dep := range missingDeps {
 					fmt.Printf("\n")
-					installTool(dep)
+					installTool(dep, tools.InstallOptions{})
 				}
 				// Refresh platform detection after installing dependencies
 				platform.Refresh()
@@ -122,7 +416,7 @@ func installTool(name string) {
 			printError(fmt.Sprintf("No installation method available for %s on this system", name))
 			showMissingDependencies(tool)
 			fmt.Printf("  Visit %s for manual installation\n", tool.Website)
-			return
+			return false
 		}
 	}
 
@@ -130,8 +424,8 @@ func installTool(name string) {
 	var err error
 
 	// If --method flag is specified, use it (backward compatibility)
-	if installMethod != "" {
-		method = installer.InstallMethod(installMethod)
+	if opts.Method != "" {
+		method = opts.Method
 		found := false
 		for _, m := range availableMethods {
 			if m == method {
@@ -140,9 +434,23 @@ func installTool(name string) {
 			}
 		}
 		if !found {
-			printError(fmt.Sprintf("Method '%s' not available for %s", installMethod, name))
+			printError(fmt.Sprintf("Method '%s' not available for %s", method, name))
 			fmt.Printf("  Available methods: %v\n", availableMethods)
-			return
+			return false
+		}
+	} else if !opts.IsZero() || tool.Instance != "" {
+		// Container options and instances only apply to the docker method
+		method = installer.MethodDocker
+		found := false
+		for _, m := range availableMethods {
+			if m == method {
+				found = true
+				break
+			}
+		}
+		if !found {
+			printError(fmt.Sprintf("--port, --env, --volume, --name and --instance need the docker method, which is not available for %s", tool.Name))
+			return false
 		}
 	} else {
 		// If multiple methods available, show interactive menu
@@ -150,7 +458,7 @@ func installTool(name string) {
 			method, err = promptMethodSelection(name, availableMethods)
 			if err != nil {
 				printError(fmt.Sprintf("Method selection failed: %v", err))
-				return
+				return false
 			}
 			fmt.Printf("\nSelected installation method: \033[32m%s\033[0m\n\n", method)
 		} else {
@@ -159,21 +467,46 @@ func installTool(name string) {
 		}
 	}
 
+	if unmet := tool.UnmetRequires(method); len(unmet) > 0 {
+		printError(fmt.Sprintf("Installing %s with %s requires %s, install it first", name, method, strings.Join(unmet, ", ")))
+		return false
+	}
+
 	// Check dependencies
 	if !skipDepsCheck {
 		checkDependencies(method)
+		if !ensureRuntimes(tool, method) {
+			return false
+		}
+	}
+
+	if !runPreflight(tool, method) {
+		return false
 	}
 
 	spinner := util.NewSpinner(fmt.Sprintf("Installing %s using %s...", name, method))
 	spinner.Start()
 
-	if err := tool.Install(method); err != nil {
+	opts.ChoosePort = portChooser(spinner)
+	if err := tool.InstallWithOptions(method, opts); err != nil {
 		spinner.Error(fmt.Sprintf("Failed to install %s: %v", name, err))
-		return
+		offerMirrorSetup(err)
+		return false
 	}
 
 	// Verify installation
 	if tool.IsInstalled() {
+		// Services started by the install must be ready to count as installed;
+		// a native server isn't started until 'getoai start'
+		kind := tool.ServiceKind()
+		if kind == tools.ServiceContainer || kind == tools.ServiceCompose || (kind == tools.ServiceNative && tool.Status().Running) {
+			spinner.Stop()
+			if !waitReady(tool) {
+				spinner.Info(fmt.Sprintf("%s installed, but it is not ready", name))
+				showServiceHints(tool)
+				return false
+			}
+		}
 		version := tool.GetVersion()
 		if version == "N/A" || version == "not installed" {
 			// Desktop app without version info
@@ -181,6 +514,10 @@ func installTool(name string) {
 		} else {
 			spinner.Success(fmt.Sprintf("%s installed successfully! (version: %s)", name, version))
 		}
+		if kind != tools.ServiceNone {
+			connectConsumers(tool)
+		}
+		showServiceHints(tool)
 	} else {
 		// For desktop apps (with AppName), show different message
 		if tool.AppName != "" {
@@ -191,6 +528,63 @@ func installTool(name string) {
 			showPathHint(method)
 		}
 	}
+	return true
+}
+
+// portChooser resolves busy host ports during an install: --auto-port
+// takes the next free port, otherwise the user is asked
+func portChooser(spinner *util.Spinner) installer.PortChooser {
+	return func(busy, free int) (int, bool) {
+		if free == 0 {
+			spinner.Stop()
+			printError(fmt.Sprintf("Port %d is already in use and no free port was found near it", busy))
+			return 0, false
+		}
+		if autoPort {
+			fmt.Printf("\r\033[KPort %d is already in use, using %d instead\n", busy, free)
+			return free, true
+		}
+
+		spinner.Stop()
+		defer spinner.Start()
+		return free, util.Confirm(fmt.Sprintf("Port %d is already in use. Use %d instead?", busy, free), true)
+	}
+}
+
+// connectConsumers offers to recreate the installed UIs that use the new
+// tool as a backend (e.g. open-webui after ollama), so they pick up its URL
+func connectConsumers(tool *tools.Tool) {
+	if tool.Instance != "" {
+		// Consumers stay on the default install
+		return
+	}
+	for _, consumer := range tool.Consumers() {
+		confirm := func() bool {
+			return util.Confirm(fmt.Sprintf("Recreate the %s container to connect it to %s? Its data volumes are kept.", consumer.Name, tool.Name), true)
+		}
+		changed, err := consumer.ConnectBackends(nil, confirm)
+		if err != nil {
+			printError(fmt.Sprintf("Failed to connect %s to %s: %v", consumer.Name, tool.Name, err))
+			continue
+		}
+		if changed {
+			printSuccess(fmt.Sprintf("%s now uses %s", consumer.Name, tool.Name))
+		}
+	}
+}
+
+// showServiceHints lists the lifecycle commands for tools that run as a service
+func showServiceHints(tool *tools.Tool) {
+	if tool.ServiceKind() == tools.ServiceNone {
+		return
+	}
+	fmt.Println()
+	fmt.Println("Useful commands:")
+	fmt.Printf("  getoai status %-16s Show status\n", tool.ID())
+	fmt.Printf("  getoai logs -f %-15s Follow logs\n", tool.ID())
+	fmt.Printf("  getoai stop %-18s Stop the service\n", tool.ID())
+	fmt.Printf("  getoai start %-17s Start the service\n", tool.ID())
+	fmt.Printf("  getoai restart %-15s Restart the service\n", tool.ID())
 }
 
 func suggestSimilar(name string) {
@@ -214,26 +608,26 @@ func checkDependencies(method installer.InstallMethod) {
 		if !p.HasNpm {
 			printInfo("npm not found. Install Node.js first:")
 			fmt.Println("  macOS:   brew install node")
-			fmt.Println("  Linux:   apt install nodejs npm / dnf install nodejs npm")
+			fmt.Printf("  Linux:   %s\n", linuxInstallHint(p, "nodejs npm", "nodejs npm", "nodejs npm"))
 			fmt.Println("  Windows: winget install OpenJS.NodeJS")
 		}
 	case installer.MethodPip:
 		if !p.HasPip && !p.HasPip3 {
 			printInfo("pip not found. Install Python first:")
 			fmt.Println("  macOS:   brew install python")
-			fmt.Println("  Linux:   apt install python3-pip / dnf install python3-pip")
+			fmt.Printf("  Linux:   %s\n", linuxInstallHint(p, "python3-pip", "python3-pip", "py3-pip"))
 			fmt.Println("  Windows: winget install Python.Python.3")
 		}
 	case installer.MethodGo:
 		if !p.HasGo {
 			printInfo("go not found. Install Go first:")
 			fmt.Println("  macOS:   brew install go")
-			fmt.Println("  Linux:   apt install golang / dnf install golang")
+			fmt.Printf("  Linux:   %s\n", linuxInstallHint(p, "golang", "golang", "go"))
 			fmt.Println("  Windows: winget install GoLang.Go")
 		}
 	case installer.MethodDocker:
-		if !p.HasDocker {
-			printInfo("docker not found. Install Docker first:")
+		if p.ContainerRuntime == "" {
+			printInfo("No container runtime found. Install Docker (or Podman) first:")
 			fmt.Println("  Visit: https://docs.docker.com/get-docker/")
 		}
 	case installer.MethodBrew:
@@ -244,6 +638,20 @@ func checkDependencies(method installer.InstallMethod) {
 	}
 }
 
+// linuxInstallHint returns the package install command for the detected
+// distribution, or a generic hint when the distribution is unknown
+func linuxInstallHint(p *platform.Platform, aptPkg, dnfPkg, apkPkg string) string {
+	switch {
+	case p.IsDebianLike():
+		return "apt install " + aptPkg
+	case p.Distro == "alpine":
+		return "apk add " + apkPkg
+	case p.HasDnf:
+		return "dnf install " + dnfPkg
+	}
+	return fmt.Sprintf("apt install %s / dnf install %s", aptPkg, dnfPkg)
+}
+
 func showMissingDependencies(tool *tools.Tool) {
 	p := platform.Detect()
 	var missing []string
@@ -263,7 +671,7 @@ func showMissingDependencies(tool *tools.Tool) {
 				missing = append(missing, "go (install Go)")
 			}
 		case installer.MethodDocker:
-			if !p.HasDocker {
+			if p.ContainerRuntime == "" {
 				missing = append(missing, "docker")
 			}
 		case installer.MethodBrew:
@@ -301,7 +709,7 @@ var dependencyMap = map[installer.InstallMethod]struct {
 }{
 	installer.MethodNpm:    {toolName: "node", desc: "Node.js"},
 	installer.MethodPip:    {toolName: "", desc: "Python"}, // Python installation is complex, skip auto-install
-	installer.MethodGo:     {toolName: "", desc: "Go"},     // Go installation is complex, skip auto-install
+	installer.MethodGo:     {toolName: "go", desc: "Go"},
 	installer.MethodDocker: {toolName: "docker", desc: "Docker"},
 }
 
@@ -324,8 +732,13 @@ func getMissingDependencies(tool *tools.Tool) []string {
 				missing = true
 				depInfo = dependencyMap[method]
 			}
+		case installer.MethodGo:
+			if !p.HasGo {
+				missing = true
+				depInfo = dependencyMap[method]
+			}
 		case installer.MethodDocker:
-			if !p.HasDocker {
+			if p.ContainerRuntime == "" {
 				missing = true
 				depInfo = dependencyMap[method]
 			}
@@ -375,9 +788,112 @@ func getMethodForDep(toolName string) installer.InstallMethod {
 	switch toolName {
 	case "node":
 		return installer.MethodNpm
+	case "go":
+		return installer.MethodGo
 	case "docker":
 		return installer.MethodDocker
 	default:
 		return ""
 	}
 }
+
+// runtimeDependencies maps runtimes to the tool that provides them (empty if
+// getoai can't install it) and a hint for upgrading manually
+var runtimeDependencies = map[string]struct {
+	toolName string
+	hint     string
+}{
+	"node":    {toolName: "node", hint: "Install Node.js LTS from https://nodejs.org or run: nvm install --lts"},
+	"python":  {toolName: "", hint: "Install a matching Python from https://www.python.org/downloads or use pyenv"},
+	"go":      {toolName: "go", hint: "Install a newer Go from https://go.dev/dl"},
+	"docker":  {toolName: "docker", hint: "Update Docker: https://docs.docker.com/get-docker/"},
+	"compose": {toolName: "", hint: "Update Docker Desktop or install the docker-compose-plugin package"},
+}
+
+// ensureRuntimes checks the runtime versions required by the install method.
+// It offers to install or update runtimes getoai manages and returns false
+// if the requirements are still not met.
+func ensureRuntimes(tool *tools.Tool, method installer.InstallMethod) bool {
+	unmet := tool.UnmetRuntimes(method)
+	if len(unmet) == 0 {
+		return true
+	}
+
+	printError(fmt.Sprintf("%s requires newer runtimes to install via %s:", tool.Name, method))
+	for _, req := range unmet {
+		found := req.Found
+		if found == "" {
+			found = "not installed"
+		}
+		fmt.Printf("  %s %s (found: %s)\n", req.Runtime, req.Constraint, found)
+	}
+
+	// Offer to install the runtimes getoai knows how to install
+	var deps []string
+	for _, req := range unmet {
+		if dep := runtimeDependencies[req.Runtime].toolName; dep != "" {
+			deps = append(deps, dep)
+		}
+	}
+	if len(deps) > 0 && promptInstallDependencies(deps) {
+		for _, dep := range deps {
+			fmt.Println()
+			if depTool, ok := tools.Get(dep); ok && depTool.IsInstalled() {
+				updateTool(dep)
+			} else {
+				installTool(dep, tools.InstallOptions{})
+			}
+		}
+		platform.Refresh()
+
+		unmet = tool.UnmetRuntimes(method)
+		if len(unmet) == 0 {
+			fmt.Printf("\nRuntimes updated. Continuing with %s installation...\n\n", tool.Name)
+			return true
+		}
+	}
+
+	fmt.Println()
+	for _, req := range unmet {
+		fmt.Printf("  %s\n", runtimeDependencies[req.Runtime].hint)
+	}
+	fmt.Println("  Use --skip-deps to install anyway")
+	return false
+}
+
+// runPreflight checks the system resources declared by the tool and returns
+// false if the install should not proceed
+func runPreflight(tool *tools.Tool, method installer.InstallMethod) bool {
+	checks := tool.Preflight(method)
+	if len(checks) == 0 {
+		return true
+	}
+
+	fmt.Printf("Preflight checks for %s:\n", tool.Name)
+	blocked := false
+	for _, c := range checks {
+		status := "\033[32m✓\033[0m"
+		if !c.OK && c.Fatal {
+			status = "\033[31m✗\033[0m"
+			blocked = true
+		} else if !c.OK {
+			status = "\033[33m!\033[0m"
+		}
+		fmt.Printf("  %s %-7s %s (requires %s)\n", status, c.Resource+":", c.Measured, c.Required)
+	}
+	if tool.Resources.DownloadGB > 0 {
+		fmt.Printf("  Approximate download size: %g GB\n", tool.Resources.DownloadGB)
+	}
+	fmt.Println()
+
+	if blocked {
+		if forceInstall {
+			printInfo("Continuing despite insufficient resources (--force)")
+			return true
+		}
+		printError(fmt.Sprintf("Not enough resources to run %s reliably", tool.Name))
+		fmt.Println("  Use --force to install anyway")
+		return false
+	}
+	return true
+}
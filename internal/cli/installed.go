@@ -17,7 +17,7 @@ var installedCmd = &cobra.Command{
 }
 
 func runInstalled(cmd *cobra.Command, args []string) {
-	allTools := tools.List()
+	allTools := append(tools.List(), tools.Instances()...)
 
 	var installed []*tools.Tool
 	for _, tool := range allTools {
@@ -28,7 +28,7 @@ func runInstalled(cmd *cobra.Command, args []string) {
 
 	// Sort by name
 	sort.Slice(installed, func(i, j int) bool {
-		return installed[i].Name < installed[j].Name
+		return installed[i].ID() < installed[j].ID()
 	})
 
 	if len(installed) == 0 {
@@ -43,7 +43,7 @@ func runInstalled(cmd *cobra.Command, args []string) {
 	fmt.Printf("%-15s %-10s %s\n", "----", "--------", "-------")
 
 	for _, tool := range installed {
-		fmt.Printf("%-15s %-10s %s\n", tool.Name, tool.Category, tool.GetVersion())
+		fmt.Printf("%-15s %-10s %s\n", tool.ID(), tool.Category, tool.GetVersion())
 	}
 	fmt.Println()
 }
@@ -0,0 +1,208 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/getoai/getoai-cli/internal/tools"
+	"github.com/getoai/getoai-cli/internal/util"
+)
+
+var startCmd = &cobra.Command{
+	Use:   "start <tool> [tools...]",
+	Short: "Start an installed service",
+	Long: `Start the container, docker compose app or background server of an
+installed tool, and wait until it passes its readiness check.
+
+Examples:
+  getoai start open-webui
+  getoai start dify
+  getoai start ollama          # runs 'ollama serve' in the background`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runLifecycle(args, "Starting", "started", (*tools.Tool).Start, true)
+	},
+}
+
+var stopCmd = &cobra.Command{
+	Use:   "stop <tool> [tools...]",
+	Short: "Stop a running service",
+	Long: `Stop the container, docker compose app or background server of an
+installed tool. Nothing is removed; use 'getoai start' to bring it back.
+
+Examples:
+  getoai stop open-webui
+  getoai stop dify ollama`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runLifecycle(args, "Stopping", "stopped", (*tools.Tool).Stop, false)
+	},
+}
+
+var restartCmd = &cobra.Command{
+	Use:   "restart <tool> [tools...]",
+	Short: "Restart a service",
+	Long: `Restart the container, docker compose app or background server of an
+installed tool.
+
+Examples:
+  getoai restart open-webui`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runLifecycle(args, "Restarting", "restarted", (*tools.Tool).Restart, true)
+	},
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status [tool...]",
+	Short: "Show the status of installed services",
+	Long: `Show whether the services of installed tools are running.
+If no tool is specified, shows all installed services.
+
+Examples:
+  getoai status
+  getoai status open-webui open-webui:staging`,
+	Run: runStatus,
+}
+
+var logsCmd = &cobra.Command{
+	Use:   "logs <tool>",
+	Short: "Show the logs of a service",
+	Long: `Show the logs of an installed tool's container, docker compose app or
+background server.
+
+Examples:
+  getoai logs open-webui
+  getoai logs -f dify
+  getoai logs --tail 50 ollama`,
+	Args: cobra.ExactArgs(1),
+	Run:  runLogs,
+}
+
+var (
+	followLogs bool
+	logsTail   int
+)
+
+func init() {
+	logsCmd.Flags().BoolVarP(&followLogs, "follow", "f", false, "Keep streaming new log output")
+	logsCmd.Flags().IntVarP(&logsTail, "tail", "n", 100, "Number of lines to show from the end (0 for all)")
+	rootCmd.AddCommand(startCmd)
+	rootCmd.AddCommand(stopCmd)
+	rootCmd.AddCommand(restartCmd)
+	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(logsCmd)
+}
+
+func runLifecycle(names []string, verb, done string, action func(*tools.Tool) error, wait bool) {
+	for _, name := range names {
+		tool, ok := tools.Get(name)
+		if !ok {
+			printError(fmt.Sprintf("Unknown tool: %s", name))
+			continue
+		}
+
+		fmt.Printf("%s %s...\n", verb, name)
+		if err := action(tool); err != nil {
+			printError(fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		printSuccess(fmt.Sprintf("%s %s", name, done))
+		if wait {
+			waitReady(tool)
+		}
+	}
+}
+
+// waitReady waits with a spinner for a started service to pass its
+// readiness probe. On failure the last log lines are shown.
+func waitReady(tool *tools.Tool) bool {
+	probe := tool.ReadyProbe()
+	if probe == nil {
+		return true
+	}
+
+	spinner := util.NewSpinner(fmt.Sprintf("Waiting for %s to become ready (%s)...", tool.ID(), probe))
+	spinner.Start()
+	if err := tool.WaitReady(); err != nil {
+		spinner.Error(fmt.Sprintf("%s is not ready: %v", tool.ID(), err))
+		fmt.Println()
+		fmt.Println("Last log lines:")
+		_ = tool.Logs(false, 20)
+		fmt.Println()
+		fmt.Printf("  Run 'getoai logs -f %s' to follow the logs\n", tool.ID())
+		return false
+	}
+	spinner.Success(fmt.Sprintf("%s is ready", tool.ID()))
+	return true
+}
+
+func runStatus(cmd *cobra.Command, args []string) {
+	var services []*tools.Tool
+	if len(args) == 0 {
+		for _, tool := range append(tools.List(), tools.Instances()...) {
+			if tool.ServiceKind() != tools.ServiceNone {
+				services = append(services, tool)
+			}
+		}
+		if len(services) == 0 {
+			fmt.Println("No services installed.")
+			fmt.Println("Use 'getoai install <tool>' to install one, e.g. open-webui or ollama")
+			return
+		}
+	} else {
+		for _, name := range args {
+			tool, ok := tools.Get(name)
+			if !ok {
+				printError(fmt.Sprintf("Unknown tool: %s", name))
+				continue
+			}
+			services = append(services, tool)
+		}
+	}
+
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].ID() < services[j].ID()
+	})
+	printStatusTable(services)
+}
+
+// printStatusTable prints the service status of each tool
+func printStatusTable(services []*tools.Tool) {
+	fmt.Println()
+	fmt.Printf("%-18s %-10s %-14s %s\n", "NAME", "TYPE", "STATUS", "DETAILS")
+	fmt.Printf("%-18s %-10s %-14s %s\n", "----", "----", "------", "-------")
+	for _, tool := range services {
+		status := tool.Status()
+		kind := string(status.Kind)
+		if kind == "" {
+			kind = "-"
+		}
+		state := status.State
+		if status.Kind == tools.ServiceNone {
+			state = "not installed"
+		}
+		if status.State == "running" {
+			state = fmt.Sprintf("\033[32m%-14s\033[0m", state)
+		} else if status.Running {
+			state = fmt.Sprintf("\033[33m%-14s\033[0m", state)
+		} else {
+			state = fmt.Sprintf("%-14s", state)
+		}
+		fmt.Printf("%-18s %-10s %s %s\n", tool.ID(), kind, state, status.Detail)
+	}
+	fmt.Println()
+}
+
+func runLogs(cmd *cobra.Command, args []string) {
+	tool, ok := tools.Get(args[0])
+	if !ok {
+		printError(fmt.Sprintf("Unknown tool: %s", args[0]))
+		return
+	}
+	if err := tool.Logs(followLogs, logsTail); err != nil {
+		printError(err.Error())
+	}
+}
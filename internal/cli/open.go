@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/getoai/getoai-cli/internal/installer"
+	"github.com/getoai/getoai-cli/internal/tools"
+)
+
+var openCmd = &cobra.Command{
+	Use:   "open <tool>",
+	Short: "Open a tool's web UI in the browser",
+	Long: `Open the web UI of an installed tool, such as open-webui, lobechat, dify
+or flowise, in the default browser. The address is derived from the port
+the tool was installed with.
+
+Examples:
+  getoai open open-webui
+  getoai open dify --print      # Only print the URL, e.g. over SSH`,
+	Args: cobra.ExactArgs(1),
+	Run:  runOpen,
+}
+
+var openPrint bool
+
+func init() {
+	openCmd.Flags().BoolVar(&openPrint, "print", false, "Print the URL instead of opening a browser")
+	rootCmd.AddCommand(openCmd)
+}
+
+func runOpen(cmd *cobra.Command, args []string) {
+	name := args[0]
+	tool, ok := tools.Get(name)
+	if !ok {
+		printError(fmt.Sprintf("Unknown tool: %s", name))
+		return
+	}
+
+	url, err := tool.AccessURL()
+	if err != nil {
+		printError(err.Error())
+		return
+	}
+	if openPrint {
+		fmt.Println(url)
+		return
+	}
+
+	if status := tool.Status(); !status.Running {
+		printInfo(fmt.Sprintf("%s is %s, start it with 'getoai start %s'", name, status.State, name))
+	}
+	fmt.Printf("Opening %s...\n", url)
+	if err := installer.OpenURL(url); err != nil {
+		printError(fmt.Sprintf("%v, open %s manually", err, url))
+	}
+}
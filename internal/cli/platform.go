@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/getoai/getoai-cli/internal/platform"
+	"github.com/getoai/getoai-cli/internal/util"
+)
+
+var platformCmd = &cobra.Command{
+	Use:   "platform",
+	Short: "Show detected platform information",
+	Long: `Show what getoai detected about this system: OS, architecture,
+Linux distribution and libc, CPU features, memory, free disk space and
+the versions of installed runtimes.
+
+Examples:
+  getoai platform
+  getoai platform --json`,
+	Args: cobra.NoArgs,
+	Run:  runPlatform,
+}
+
+var platformJSON bool
+
+func init() {
+	platformCmd.Flags().BoolVar(&platformJSON, "json", false, "Output as JSON")
+	rootCmd.AddCommand(platformCmd)
+}
+
+// platformReport is the JSON representation of the detected platform
+type platformReport struct {
+	OS             string            `json:"os"`
+	Arch           string            `json:"arch"`
+	Distro         string            `json:"distro,omitempty"`
+	DistroVersion  string            `json:"distro_version,omitempty"`
+	DistroLike     []string          `json:"distro_like,omitempty"`
+	Libc           string            `json:"libc,omitempty"`
+	WSL            bool              `json:"wsl"`
+	CPUCores       int               `json:"cpu_cores"`
+	CPUFeatures    []string          `json:"cpu_features"`
+	MemoryTotal    uint64            `json:"memory_total_bytes"`
+	DiskFree       uint64            `json:"disk_free_bytes"`
+	PackageManager string            `json:"package_manager,omitempty"`
+	Runtimes       map[string]string `json:"runtimes"`
+}
+
+func runPlatform(cmd *cobra.Command, args []string) {
+	p := platform.Detect()
+
+	report := platformReport{
+		OS:             p.OS,
+		Arch:           p.Arch,
+		Distro:         p.Distro,
+		DistroVersion:  p.DistroVersion,
+		DistroLike:     p.DistroLike,
+		Libc:           p.Libc,
+		WSL:            p.IsWSL,
+		CPUCores:       p.CPUCores,
+		CPUFeatures:    p.CPUFeatures,
+		MemoryTotal:    p.MemoryTotal(),
+		DiskFree:       p.FreeDisk(p.HomeDir),
+		PackageManager: p.GetPackageManager(),
+		Runtimes:       make(map[string]string),
+	}
+	if report.CPUFeatures == nil {
+		report.CPUFeatures = []string{}
+	}
+	for _, name := range platform.RuntimeNames() {
+		report.Runtimes[name] = p.RuntimeVersion(name)
+	}
+
+	if platformJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			printError(fmt.Sprintf("Failed to encode platform info: %v", err))
+			return
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Println()
+	fmt.Printf("Platform:    %s\n", p.String())
+	if distro := p.DistroName(); distro != "" {
+		fmt.Printf("Distro:      %s\n", distro)
+	}
+	if p.IsWSL {
+		fmt.Println("WSL:         yes")
+	}
+	cpu := fmt.Sprintf("%d cores", report.CPUCores)
+	if len(report.CPUFeatures) > 0 {
+		cpu += fmt.Sprintf(" (%s)", strings.Join(report.CPUFeatures, ", "))
+	}
+	fmt.Printf("CPU:         %s\n", cpu)
+	fmt.Printf("Memory:      %s\n", formatSize(report.MemoryTotal))
+	fmt.Printf("Disk free:   %s (%s)\n", formatSize(report.DiskFree), p.HomeDir)
+	if report.PackageManager != "" {
+		fmt.Printf("Packages:    %s\n", report.PackageManager)
+	}
+
+	fmt.Println()
+	fmt.Println("Runtimes:")
+	for _, name := range platform.RuntimeNames() {
+		version := report.Runtimes[name]
+		if version == "" {
+			version = "\033[33mnot installed\033[0m"
+		}
+		fmt.Printf("  %-9s %s\n", name, version)
+	}
+	fmt.Println()
+}
+
+// formatSize formats a byte count, showing "unknown" for zero
+func formatSize(n uint64) string {
+	if n == 0 {
+		return "unknown"
+	}
+	return util.FormatBytes(n)
+}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/getoai/getoai-cli/internal/tools"
+)
+
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback <tool>",
+	Short: "Switch back to the container replaced by the last update",
+	Long: `Replace a tool's container with the one kept stopped by the last
+'getoai update', and start it. Data in volumes is shared by both containers.
+
+Examples:
+  getoai update open-webui
+  getoai rollback open-webui`,
+	Args: cobra.ExactArgs(1),
+	Run:  runRollback,
+}
+
+func init() {
+	rootCmd.AddCommand(rollbackCmd)
+}
+
+func runRollback(cmd *cobra.Command, args []string) {
+	name := args[0]
+	tool, ok := tools.Get(name)
+	if !ok {
+		printError(fmt.Sprintf("Unknown tool: %s", name))
+		return
+	}
+
+	if !tool.CanRollback() {
+		printInfo(fmt.Sprintf("No previous container of %s to roll back to", name))
+		return
+	}
+
+	fmt.Printf("Rolling back %s...\n", name)
+	if err := tool.Rollback(); err != nil {
+		printError(fmt.Sprintf("Failed to roll back %s: %v", name, err))
+		return
+	}
+	printSuccess(fmt.Sprintf("%s rolled back to the previous container", name))
+}
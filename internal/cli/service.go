@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/getoai/getoai-cli/internal/installer"
+	"github.com/getoai/getoai-cli/internal/tools"
+)
+
+var serviceCmd = &cobra.Command{
+	Use:   "service",
+	Short: "Run native servers as systemd user services",
+	Long: `Run the server of a natively installed tool, such as ollama, llama-cpp,
+koboldcpp, xinference or tabby, as a systemd user service: it starts when
+you log in and is restarted if it fails.
+
+getoai start, stop, restart, status and logs keep working for enabled
+services, through systemd.
+
+Examples:
+  getoai service enable ollama
+  getoai service enable llama-cpp -- -m ~/models/qwen2.5-7b.gguf
+  getoai service disable ollama`,
+}
+
+var serviceEnableCmd = &cobra.Command{
+	Use:   "enable <tool> [-- args...]",
+	Short: "Install and start a systemd user service for a tool's server",
+	Long: `Install a systemd user service running the tool's server with its
+default arguments, followed by the ones given after --, and start it.
+Enabling it again replaces the service with the new arguments.
+
+Examples:
+  getoai service enable ollama --env OLLAMA_HOST=0.0.0.0:11434
+  getoai service enable llama-cpp -- -m ~/models/qwen2.5-7b.gguf -c 8192
+  getoai service enable xinference --restart always`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runServiceEnable,
+}
+
+var serviceDisableCmd = &cobra.Command{
+	Use:   "disable <tool> [tools...]",
+	Short: "Stop and remove the systemd user service of a tool",
+	Long: `Stop the systemd user service of a tool's server and remove it. The
+server can still be started by hand with 'getoai start'.
+
+Examples:
+  getoai service disable ollama`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runServiceDisable,
+}
+
+var (
+	serviceEnv     []string
+	serviceRestart string
+)
+
+func init() {
+	serviceEnableCmd.Flags().StringArrayVarP(&serviceEnv, "env", "e", nil, "Environment variable for the server (KEY=VALUE, repeatable)")
+	serviceEnableCmd.Flags().StringVar(&serviceRestart, "restart", "", fmt.Sprintf("Restart policy (%s; default %s)", strings.Join(installer.RestartPolicies, ", "), installer.DefaultRestart))
+	serviceCmd.AddCommand(serviceEnableCmd)
+	serviceCmd.AddCommand(serviceDisableCmd)
+	rootCmd.AddCommand(serviceCmd)
+}
+
+func runServiceEnable(cmd *cobra.Command, args []string) {
+	name := args[0]
+	var extra []string
+	if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+		if dash != 1 {
+			printError("Pass a single tool before --, e.g. getoai service enable llama-cpp -- -m model.gguf")
+			return
+		}
+		extra = args[1:]
+	} else if len(args) > 1 {
+		printError("Pass server arguments after --, e.g. getoai service enable llama-cpp -- -m model.gguf")
+		return
+	}
+
+	tool, ok := tools.Get(name)
+	if !ok {
+		printError(fmt.Sprintf("Unknown tool: %s", name))
+		return
+	}
+	env := make(map[string]string)
+	for _, kv := range serviceEnv {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok || key == "" {
+			printError(fmt.Sprintf("Invalid --env %q, expected KEY=VALUE", kv))
+			return
+		}
+		env[key] = value
+	}
+
+	fmt.Printf("Enabling %s as a systemd user service...\n", name)
+	if err := tool.EnableService(extra, env, serviceRestart); err != nil {
+		printError(fmt.Sprintf("%s: %v", name, err))
+		return
+	}
+	printSuccess(fmt.Sprintf("%s enabled (%s)", name, installer.UnitName(name)))
+	fmt.Printf("  Unit file: %s\n", installer.UnitPath(name))
+	if !installer.LingerEnabled() {
+		fmt.Println("  It runs while you are logged in. To keep it running after you log out:")
+		fmt.Println("    loginctl enable-linger $USER")
+	}
+	waitReady(tool)
+}
+
+func runServiceDisable(cmd *cobra.Command, args []string) {
+	for _, name := range args {
+		tool, ok := tools.Get(name)
+		if !ok {
+			printError(fmt.Sprintf("Unknown tool: %s", name))
+			continue
+		}
+		if err := tool.DisableService(); err != nil {
+			printError(fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		printSuccess(fmt.Sprintf("%s service disabled and stopped", name))
+	}
+}
@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/getoai/getoai-cli/internal/tools"
+)
+
+var stackCmd = &cobra.Command{
+	Use:   "stack",
+	Short: "Manage curated multi-tool stacks",
+	Long: `Stacks bundle tools that are commonly used together, such as Ollama with
+Open WebUI. Install one with 'getoai install stack/<name>': its tools are
+installed in order and wired to each other over the shared getoai network.
+
+Examples:
+  getoai stack list
+  getoai install stack/local-chat
+  getoai stack status local-chat
+  getoai stack down local-chat
+  getoai stack up local-chat`,
+}
+
+var stackListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the available stacks",
+	Args:  cobra.NoArgs,
+	Run:   runStackList,
+}
+
+var stackStatusCmd = &cobra.Command{
+	Use:   "status <stack>",
+	Short: "Show the status of a stack's services",
+	Args:  cobra.ExactArgs(1),
+	Run:   runStackStatus,
+}
+
+var stackUpCmd = &cobra.Command{
+	Use:   "up <stack>",
+	Short: "Start a stack's services in order",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		names, ok := stackServices(args[0], false)
+		if ok {
+			runLifecycle(names, "Starting", "started", (*tools.Tool).Start, true)
+		}
+	},
+}
+
+var stackDownCmd = &cobra.Command{
+	Use:   "down <stack>",
+	Short: "Stop a stack's services in reverse order",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		names, ok := stackServices(args[0], true)
+		if ok {
+			runLifecycle(names, "Stopping", "stopped", (*tools.Tool).Stop, false)
+		}
+	},
+}
+
+func init() {
+	stackCmd.AddCommand(stackListCmd)
+	stackCmd.AddCommand(stackStatusCmd)
+	stackCmd.AddCommand(stackUpCmd)
+	stackCmd.AddCommand(stackDownCmd)
+	rootCmd.AddCommand(stackCmd)
+}
+
+func lookupStack(name string) (*tools.Stack, bool) {
+	stack, ok := tools.GetStack(name)
+	if !ok {
+		printError(fmt.Sprintf("Unknown stack: %s", name))
+		fmt.Println("  Run 'getoai stack list' to see the available stacks")
+	}
+	return stack, ok
+}
+
+// stackServices returns the installed services of a stack, in start order
+// or, for stopping, in reverse
+func stackServices(name string, reverse bool) ([]string, bool) {
+	stack, ok := lookupStack(name)
+	if !ok {
+		return nil, false
+	}
+	var names []string
+	for _, member := range stack.ToolNames() {
+		tool, ok := tools.Get(member)
+		if !ok || tool.ServiceKind() == tools.ServiceNone {
+			continue
+		}
+		if reverse {
+			names = append([]string{member}, names...)
+		} else {
+			names = append(names, member)
+		}
+	}
+	if len(names) == 0 {
+		printInfo(fmt.Sprintf("No service of stack %s is installed", stack.Name))
+		fmt.Printf("  Install it with 'getoai install %s%s'\n", tools.StackPrefix, stack.Name)
+		return nil, false
+	}
+	return names, true
+}
+
+func runStackList(cmd *cobra.Command, args []string) {
+	fmt.Println()
+	fmt.Printf("%-14s %-28s %s\n", "NAME", "TOOLS", "DESCRIPTION")
+	fmt.Printf("%-14s %-28s %s\n", "----", "-----", "-----------")
+	for _, stack := range tools.ListStacks() {
+		fmt.Printf("%-14s %-28s %s\n", stack.Name, strings.Join(stack.ToolNames(), ", "), stack.Description)
+	}
+	fmt.Println()
+	fmt.Printf("Install a stack with 'getoai install %s<name>'\n", tools.StackPrefix)
+}
+
+func runStackStatus(cmd *cobra.Command, args []string) {
+	stack, ok := lookupStack(args[0])
+	if !ok {
+		return
+	}
+	var members []*tools.Tool
+	for _, name := range stack.ToolNames() {
+		if tool, ok := tools.Get(name); ok {
+			members = append(members, tool)
+		}
+	}
+	printStatusTable(members)
+}
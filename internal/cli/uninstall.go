@@ -19,18 +19,27 @@ var uninstallCmd = &cobra.Command{
 	Short:   "Uninstall AI tools",
 	Long: `Uninstall one or more AI tools from your system.
 
+Data is kept by default: config files, downloaded models, compose app
+directories, container volumes and images. With --purge, these are
+listed with their size and deleted too, after confirmation.
+
 Examples:
   getoai uninstall chatgpt-cli
   getoai uninstall aider llm
-  getoai rm ollama`,
+  getoai rm ollama
+  getoai uninstall --purge open-webui`,
 	Args: cobra.MinimumNArgs(1),
 	Run:  runUninstall,
 }
 
-var forceUninstall bool
+var (
+	forceUninstall bool
+	purgeUninstall bool
+)
 
 func init() {
 	uninstallCmd.Flags().BoolVarP(&forceUninstall, "force", "f", false, "Skip confirmation prompt")
+	uninstallCmd.Flags().BoolVar(&purgeUninstall, "purge", false, "Also delete the tool's data, volumes and images")
 	rootCmd.AddCommand(uninstallCmd)
 }
 
@@ -47,19 +56,50 @@ func uninstallTool(name string) {
 		return
 	}
 
+	var purge []tools.PurgeItem
+	if purgeUninstall {
+		purge = tool.PurgePlan()
+	}
+
 	if !tool.IsInstalled() {
+		forgetInstall(name)
+		if len(purge) > 0 {
+			// Data left behind by an earlier uninstall
+			printInfo(fmt.Sprintf("%s is not installed, but its data remains", name))
+			if showPurgePlan(purge) && confirmUninstall(fmt.Sprintf("Delete the data of %s?", name)) {
+				if dir := tool.GetComposeInstallDir(); dir != "" && tool.IsDockerComposeInstall() {
+					// Stopped containers would keep the volumes in use
+					_ = installer.NewDockerInstaller().DownCompose(dir)
+				}
+				purgeData(purge)
+			}
+			return
+		}
 		printInfo(fmt.Sprintf("%s is not installed", name))
 		return
 	}
 
-	if !forceUninstall {
-		fmt.Printf("Are you sure you want to uninstall %s? [y/N] ", name)
-		reader := bufio.NewReader(os.Stdin)
-		response, _ := reader.ReadString('\n')
-		response = strings.TrimSpace(strings.ToLower(response))
-		if response != "y" && response != "yes" {
-			printInfo("Uninstall canceled")
-			return
+	if dependents := tools.Dependents(name); len(dependents) > 0 {
+		names := make([]string, len(dependents))
+		for i, t := range dependents {
+			names[i] = t.Name
+		}
+		fmt.Printf("\033[33m!\033[0m %s is required by installed tools: %s\n", name, strings.Join(names, ", "))
+		fmt.Println("  They may stop working after it is removed")
+	}
+
+	question := fmt.Sprintf("Are you sure you want to uninstall %s?", name)
+	if purgeUninstall && showPurgePlan(purge) {
+		question = fmt.Sprintf("Uninstall %s and delete this data?", name)
+	}
+	if !confirmUninstall(question) {
+		printInfo("Uninstall canceled")
+		return
+	}
+
+	if tool.ServiceEnabled() {
+		if err := tool.DisableService(); err != nil {
+			fmt.Printf("Warning: failed to remove the %s service: %v\n", name, err)
 		}
 	}
 
@@ -74,19 +114,28 @@ func uninstallTool(name string) {
 		if installDir != "" {
 			spinner.Stop()
 			dockerInst := installer.NewDockerInstaller()
-			uninstallErr = dockerInst.UninstallCompose(installDir)
+			if purgeUninstall {
+				uninstallErr = dockerInst.DownCompose(installDir)
+			} else {
+				uninstallErr = dockerInst.UninstallCompose(installDir, tool.ID())
+			}
 			if uninstallErr == nil {
+				forgetInstall(name)
 				printSuccess(fmt.Sprintf("%s stopped successfully", name))
+				purgeData(purge)
 				return
 			}
 		}
 	} else if tool.IsDockerContainerInstalled() {
 		// Handle docker container installations
 		dockerInst := installer.NewDockerInstaller()
-		config := tool.InstallMethods[installer.MethodDocker]
-		uninstallErr = dockerInst.StopContainer(config.DockerName)
+		uninstallErr = dockerInst.RemoveContainer(tool.ContainerName())
 		if uninstallErr == nil {
+			// Also drop the container kept for rollback by the last update
+			_ = installer.ForceRemoveContainer(installer.PreviousContainerName(tool.ContainerName()))
+			forgetInstall(name)
 			spinner.Success(fmt.Sprintf("%s uninstalled successfully", name))
+			purgeData(purge)
 			return
 		}
 	} else {
@@ -125,6 +174,8 @@ func uninstallTool(name string) {
 		return
 	}
 
+	forgetInstall(name)
+
 	// Verify uninstallation
 	if !tool.IsInstalled() {
 		spinner.Success(fmt.Sprintf("%s uninstalled successfully", name))
@@ -132,6 +183,75 @@ func uninstallTool(name string) {
 		spinner.Info(fmt.Sprintf("%s uninstall completed, but command still found in PATH", name))
 		fmt.Println("  You may need to restart your shell")
 	}
+	purgeData(purge)
+}
+
+// confirmUninstall asks the question unless --force is set
+func confirmUninstall(question string) bool {
+	if forceUninstall {
+		return true
+	}
+	fmt.Printf("%s [y/N] ", question)
+	reader := bufio.NewReader(os.Stdin)
+	response, _ := reader.ReadString('\n')
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response == "y" || response == "yes"
+}
+
+// showPurgePlan lists the data --purge deletes with its total size, and
+// reports whether there is any
+func showPurgePlan(items []tools.PurgeItem) bool {
+	if len(items) == 0 {
+		printInfo("No data to purge was found")
+		return false
+	}
+	fmt.Println("The following will be deleted permanently:")
+	for _, item := range items {
+		size := "unknown size"
+		if item.Size >= 0 {
+			size = util.FormatBytes(uint64(item.Size))
+		}
+		fmt.Printf("  %-6s  %s (%s)\n", item.Kind, item.Name, size)
+	}
+	total, partial := tools.PurgeSize(items)
+	if partial {
+		fmt.Printf("Total: at least %s\n", util.FormatBytes(uint64(total)))
+	} else {
+		fmt.Printf("Total: %s\n", util.FormatBytes(uint64(total)))
+	}
+	fmt.Println()
+	return true
+}
+
+// purgeData deletes the listed data, reporting what couldn't be deleted
+// (e.g. an image still used by another container)
+func purgeData(items []tools.PurgeItem) {
+	if len(items) == 0 {
+		return
+	}
+	var freed int64
+	failed := 0
+	for _, item := range items {
+		if err := item.Remove(); err != nil {
+			fmt.Printf("\033[33m!\033[0m Kept %s %s: %v\n", item.Kind, item.Name, err)
+			failed++
+			continue
+		}
+		freed += max(item.Size, 0)
+	}
+	msg := fmt.Sprintf("Purged %d of %d items, freed %s", len(items)-failed, len(items), util.FormatBytes(uint64(freed)))
+	if failed > 0 {
+		printInfo(msg)
+		return
+	}
+	printSuccess(msg)
+}
+
+// forgetInstall removes the install receipt of an uninstalled tool
+func forgetInstall(name string) {
+	if err := tools.RemoveReceipt(name); err != nil {
+		fmt.Printf("Warning: failed to remove install receipt: %v\n", err)
+	}
 }
 
 func getUninstallPackage(tool *tools.Tool, method installer.InstallMethod) string {
@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/getoai/getoai-cli/internal/installer"
 	"github.com/getoai/getoai-cli/internal/tools"
 	"github.com/getoai/getoai-cli/internal/util"
 )
@@ -15,21 +16,40 @@ var updateCmd = &cobra.Command{
 	Long: `Update one or more installed AI tools to their latest versions.
 If no tool is specified, updates all installed tools.
 
+Tools running as a docker container are recreated from the running
+container's settings (ports, env, volumes, labels) with the latest image.
+The old container is kept stopped so 'getoai rollback' can switch back.
+
+Docker compose apps are moved to the version pinned by getoai, or to the
+tag or branch given with --ref. The changes to the compose file and
+.env.example are shown for review before the app is restarted; .env and
+the app's data are kept.
+
 Examples:
   getoai update ollama
   getoai update                  # Update all installed tools
-  getoai update aider llm`,
+  getoai update aider llm
+  getoai update dify --ref 1.0.0   # Move a compose app to a tag`,
 	Run: runUpdate,
 }
 
-var updateAll bool
+var (
+	updateAll bool
+	updateRef string
+)
 
 func init() {
 	updateCmd.Flags().BoolVarP(&updateAll, "all", "a", false, "Update all installed tools")
+	updateCmd.Flags().StringVar(&updateRef, "ref", "", "Tag or branch to move a docker compose app to")
 	rootCmd.AddCommand(updateCmd)
 }
 
 func runUpdate(cmd *cobra.Command, args []string) {
+	if updateRef != "" && (len(args) != 1 || updateAll) {
+		printError("--ref applies to a single tool")
+		return
+	}
+
 	if len(args) == 0 || updateAll {
 		updateAllTools()
 		return
@@ -41,7 +61,7 @@ func runUpdate(cmd *cobra.Command, args []string) {
 }
 
 func updateAllTools() {
-	allTools := tools.List()
+	allTools := append(tools.List(), tools.Instances()...)
 	var installed []*tools.Tool
 
 	for _, tool := range allTools {
@@ -58,7 +78,7 @@ func updateAllTools() {
 	fmt.Printf("Updating %d installed tools...\n\n", len(installed))
 
 	for _, tool := range installed {
-		updateTool(tool.Name)
+		updateTool(tool.ID())
 	}
 }
 
@@ -74,6 +94,18 @@ func updateTool(name string) {
 		return
 	}
 
+	if tool.ServiceKind() == tools.ServiceCompose {
+		updateCompose(tool)
+		return
+	}
+
+	// Containers are recreated with the settings of the running one, read
+	// with docker inspect, keeping the old one around for rollback
+	if tool.ServiceKind() == tools.ServiceContainer {
+		updateContainer(tool)
+		return
+	}
+
 	spinner := util.NewSpinner(fmt.Sprintf("Updating %s...", name))
 	spinner.Start()
 
@@ -83,8 +115,20 @@ func updateTool(name string) {
 		return
 	}
 
+	// Update the way the tool was installed. Containers took the path above,
+	// where Upgrade copies the settings of the running container.
+	method := methods[0]
+	if r, _ := tools.LoadReceipt(name); r != nil {
+		for _, m := range methods {
+			if m == r.Method {
+				method = m
+				break
+			}
+		}
+	}
+
 	// For most package managers, reinstalling updates to latest version
-	err := tool.Install(methods[0])
+	err := tool.Install(method)
 	if err != nil {
 		spinner.Error(fmt.Sprintf("Failed to update %s: %v", name, err))
 		return
@@ -92,3 +136,61 @@ func updateTool(name string) {
 
 	spinner.Success(fmt.Sprintf("%s updated to %s", name, tool.GetVersion()))
 }
+
+func updateContainer(tool *tools.Tool) {
+	fmt.Printf("Updating %s...\n", tool.ID())
+	if err := tool.Upgrade(); err != nil {
+		printError(fmt.Sprintf("Failed to update %s: %v", tool.ID(), err))
+		offerMirrorSetup(err)
+		if tool.CanRollback() {
+			fmt.Printf("  Run 'getoai rollback %s' to switch back to the previous container\n", tool.ID())
+		}
+		return
+	}
+	printSuccess(fmt.Sprintf("%s updated", tool.ID()))
+	fmt.Printf("  Run 'getoai rollback %s' if the new version misbehaves\n", tool.ID())
+}
+
+func updateCompose(tool *tools.Tool) {
+	from := "an unpinned git clone"
+	if src := tool.ComposeSource(); src != nil {
+		from = src.RefName()
+	}
+	fmt.Printf("Updating %s (installed from %s)...\n", tool.ID(), from)
+
+	applied, err := tool.UpgradeCompose(updateRef, reviewComposeChanges)
+	if err != nil {
+		printError(fmt.Sprintf("Failed to update %s: %v", tool.ID(), err))
+		return
+	}
+	if !applied {
+		printInfo(fmt.Sprintf("%s was left unchanged", tool.ID()))
+		return
+	}
+	printSuccess(fmt.Sprintf("%s updated", tool.ID()))
+	waitReady(tool)
+}
+
+// reviewComposeChanges shows the diff of a compose app update and asks
+// whether to apply it
+func reviewComposeChanges(changes []installer.ComposeChange) bool {
+	if len(changes) == 0 {
+		fmt.Println("No changes to the compose file or .env.example")
+		return true
+	}
+	for _, c := range changes {
+		fmt.Printf("\n\033[1m%s\033[0m\n", c.File)
+		for _, line := range c.Diff {
+			switch line[0] {
+			case '-':
+				fmt.Printf("\033[31m%s\033[0m\n", line)
+			case '+':
+				fmt.Printf("\033[32m%s\033[0m\n", line)
+			default:
+				fmt.Println(line)
+			}
+		}
+	}
+	fmt.Println()
+	return util.Confirm("Apply these changes and restart?", true)
+}
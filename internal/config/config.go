@@ -16,16 +16,24 @@ type Config struct {
 	PypiMirror  string `json:"pypi_mirror,omitempty"`
 	GoProxy     string `json:"go_proxy,omitempty"`
 
+	// Docker image mirrors by registry ("docker.io", "ghcr.io", ...); the
+	// "*" mirror is put in front of references to the other registries
+	DockerMirror map[string]string `json:"docker_mirror,omitempty"`
+
 	// Installation preferences
 	PreferredMethod map[string]string `json:"preferred_method,omitempty"`
 
 	// Install paths
 	BinPath string `json:"bin_path,omitempty"`
+
+	// Container runtime: "docker", "podman" or "nerdctl" (auto-detected if empty)
+	ContainerRuntime string `json:"container_runtime,omitempty"`
 }
 
 var (
 	configDir  string
 	configFile string
+	dataDir    string
 	current    *Config
 )
 
@@ -36,6 +44,7 @@ func init() {
 	}
 	configDir = filepath.Join(home, ".config", "getoai")
 	configFile = filepath.Join(configDir, "config.json")
+	dataDir = filepath.Join(home, ".getoai")
 }
 
 func Load() (*Config, error) {
@@ -86,6 +95,12 @@ func GetConfigPath() string {
 	return configFile
 }
 
+// DataDir returns a path inside the getoai data directory (~/.getoai),
+// which holds compose apps, logs and other runtime state
+func DataDir(elem ...string) string {
+	return filepath.Join(append([]string{dataDir}, elem...)...)
+}
+
 func (c *Config) SetProxy(httpProxy, httpsProxy string) {
 	c.HttpProxy = httpProxy
 	c.HttpsProxy = httpsProxy
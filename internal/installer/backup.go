@@ -0,0 +1,346 @@
+package installer
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+)
+
+// backupHelperImage runs tar next to the data, so volumes and files owned
+// by other users (e.g. a database's uid) are archived with their owners
+const backupHelperImage = "alpine:3"
+
+// manifestName is the archive entry describing a backup
+const manifestName = "manifest.json"
+
+// BackupManifest describes what a backup archive holds
+type BackupManifest struct {
+	Tool      string        `json:"tool"`
+	Kind      string        `json:"kind"`              // "container" or "compose"
+	Version   string        `json:"version,omitempty"` // image version label or compose ref
+	Images    []BackupImage `json:"images,omitempty"`
+	Mounts    []BackupMount `json:"mounts"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// BackupImage is an image the backed up service ran
+type BackupImage struct {
+	Image  string `json:"image"`
+	Digest string `json:"digest,omitempty"`
+}
+
+// BackupMount is a volume or host directory in a backup
+type BackupMount struct {
+	Entry  string `json:"entry"`            // archive entry holding its tar stream
+	Source string `json:"source"`           // volume name or absolute host path when backed up
+	Target string `json:"target,omitempty"` // mount path in the container, to find it again on restore
+	Volume string `json:"volume,omitempty"` // compose volume name, without the project prefix
+}
+
+// ImageDigest returns the repo digest of a local image, its ID if it was
+// never pulled from a registry, or "" if it isn't present
+func ImageDigest(image string) string {
+	out, err := RunCommandSilent(containerCLI(), "image", "inspect", "-f", "{{join .RepoDigests \" \"}}|{{.Id}}", image)
+	if err != nil {
+		return ""
+	}
+	digests, id, _ := strings.Cut(strings.TrimSpace(out), "|")
+	if fields := strings.Fields(digests); len(fields) > 0 {
+		return fields[0]
+	}
+	return id
+}
+
+// ImageVersion returns the version label of a local image, if it has one
+func ImageVersion(image string) string {
+	out, err := RunCommandSilent(containerCLI(), "image", "inspect", "-f", `{{index .Config.Labels "org.opencontainers.image.version"}}`, image)
+	if err != nil {
+		return ""
+	}
+	if v := strings.TrimSpace(out); v != "<no value>" {
+		return v
+	}
+	return ""
+}
+
+// WriteBackup archives every mount of the manifest with a helper
+// container and writes them with the manifest to a gzipped tarball
+func WriteBackup(path string, m *BackupManifest) error {
+	tmp, err := os.MkdirTemp("", "getoai-backup-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	// Archive the mounts first, the outer tar needs their sizes
+	for i := range m.Mounts {
+		mount := &m.Mounts[i]
+		mount.Entry = fmt.Sprintf("mounts/%d.tar", i)
+		f, err := os.Create(fmt.Sprintf("%s/%d.tar", tmp, i))
+		if err != nil {
+			return err
+		}
+		err = helperTar(mount.Source, nil, f, "tar", "-cf", "-", "-C", "/data", ".")
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("failed to archive %s: %w", mount.Source, err)
+		}
+	}
+
+	manifest, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+	err = addTarEntry(tw, manifestName, int64(len(manifest)), strings.NewReader(string(manifest)))
+	for i := 0; err == nil && i < len(m.Mounts); i++ {
+		err = addTarFile(tw, m.Mounts[i].Entry, fmt.Sprintf("%s/%d.tar", tmp, i))
+	}
+	for _, closeErr := range []error{tw.Close(), gz.Close(), out.Close()} {
+		if err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		os.Remove(path)
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func addTarEntry(tw *tar.Writer, name string, size int64, r io.Reader) error {
+	hdr := &tar.Header{Name: name, Mode: 0600, Size: size, ModTime: time.Now(), Typeflag: tar.TypeReg}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := io.Copy(tw, r)
+	return err
+}
+
+func addTarFile(tw *tar.Writer, name, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return addTarEntry(tw, name, info.Size(), f)
+}
+
+// ReadBackupManifest returns the manifest of a backup archive
+func ReadBackupManifest(path string) (*BackupManifest, error) {
+	var m *BackupManifest
+	err := walkBackup(path, func(name string, r io.Reader) error {
+		if name != manifestName {
+			return nil
+		}
+		m = &BackupManifest{}
+		if err := json.NewDecoder(r).Decode(m); err != nil {
+			return fmt.Errorf("invalid manifest: %w", err)
+		}
+		return io.EOF
+	})
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, fmt.Errorf("%s is not a getoai backup: no manifest", path)
+	}
+	return m, nil
+}
+
+// restoreStaging is the directory inside each mount the archived data is
+// extracted to, before it replaces the current data
+const restoreStaging = ".getoai-restore"
+
+// RestoreBackup replaces the contents of each mount destination with the
+// archived data. dest maps archive entries to the volume name or absolute
+// host path to restore them to; entries without one are skipped. Every
+// entry is extracted next to the current data first, which is only
+// replaced once the whole archive was read.
+func RestoreBackup(path string, dest map[string]string) error {
+	var staged []string
+	err := walkBackup(path, func(name string, r io.Reader) error {
+		target, ok := dest[name]
+		if !ok {
+			return nil
+		}
+		if !IsNamedVolume(target) {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		}
+		staged = append(staged, target)
+		if err := helperTar(target, r, nil, "sh", "-c", stageScript("/data")); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", target, err)
+		}
+		return nil
+	})
+	if err != nil {
+		for _, target := range staged {
+			_ = helperTar(target, nil, nil, "rm", "-rf", "/data/"+restoreStaging)
+		}
+		return err
+	}
+
+	for _, target := range staged {
+		if err := helperTar(target, nil, nil, "sh", "-c", swapScript("/data")); err != nil {
+			return fmt.Errorf("failed to restore %s: %w (the archived data is in its %s directory)", target, err, restoreStaging)
+		}
+	}
+	return nil
+}
+
+// stageScript extracts the tar stream on stdin into the staging directory
+// of dir
+func stageScript(dir string) string {
+	staging := dir + "/" + restoreStaging
+	return fmt.Sprintf("rm -rf %[1]s && mkdir %[1]s && tar -xf - -C %[1]s", staging)
+}
+
+// swapScript replaces the contents of dir with its staging directory
+func swapScript(dir string) string {
+	return fmt.Sprintf("cd %s && find . -mindepth 1 -maxdepth 1 ! -name %[2]s -exec rm -rf {} + && "+
+		"find %[2]s -mindepth 1 -maxdepth 1 -exec mv {} . \\; && rmdir %[2]s", dir, restoreStaging)
+}
+
+// walkBackup calls fn for every entry of a backup archive until it returns
+// an error; io.EOF stops the walk without failing it
+func walkBackup(path string, fn func(name string, r io.Reader) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("%s is not a getoai backup: %w", path, err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid backup archive: %w", err)
+		}
+		if err := fn(hdr.Name, tr); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// helperTar runs a command in a throwaway container with source (a volume
+// name or host path) mounted at /data, wired to stdin and stdout
+func helperTar(source string, stdin io.Reader, stdout io.Writer, command ...string) error {
+	args := append([]string{"run", "--rm", "-i", "-v", source + ":/data", backupHelperImage}, command...)
+	cmd := exec.Command(containerCLI(), args...)
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%s", lastLine(msg))
+		}
+		return err
+	}
+	return nil
+}
+
+// ComposeProject returns the project name and volumes of a compose app:
+// the compose volume names mapped to the volume names on the engine.
+// External volumes are left out.
+func ComposeProject(composeFile string) (string, map[string]string, error) {
+	out, err := ComposeOutput(composeFile, "config", "--format", "json")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read compose config: %w", err)
+	}
+	return parseComposeProject(out)
+}
+
+func parseComposeProject(data []byte) (string, map[string]string, error) {
+	var config struct {
+		Name    string `json:"name"`
+		Volumes map[string]struct {
+			Name     string      `json:"name"`
+			External interface{} `json:"external"`
+		} `json:"volumes"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return "", nil, fmt.Errorf("invalid compose config: %w", err)
+	}
+	volumes := make(map[string]string)
+	for key, v := range config.Volumes {
+		if v.External != nil && v.External != false {
+			// Owned by something else, only used by this app
+			continue
+		}
+		name := v.Name
+		if name == "" {
+			name = config.Name + "_" + key
+		}
+		volumes[key] = name
+	}
+	return config.Name, volumes, nil
+}
+
+// ComposeContainerNames returns the fixed container names (container_name)
+// set in a compose file, sorted
+func ComposeContainerNames(composeFile string) []string {
+	out, err := ComposeOutput(composeFile, "config", "--format", "json")
+	if err != nil {
+		return nil
+	}
+	return parseComposeContainerNames(out)
+}
+
+func parseComposeContainerNames(data []byte) []string {
+	var config struct {
+		Services map[string]struct {
+			ContainerName string `json:"container_name"`
+		} `json:"services"`
+	}
+	if json.Unmarshal(data, &config) != nil {
+		return nil
+	}
+	var names []string
+	for _, s := range config.Services {
+		if s.ContainerName != "" {
+			names = append(names, s.ContainerName)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ComposeImages returns the images used by a compose app
+func ComposeImages(composeFile string) []string {
+	out, err := ComposeOutput(composeFile, "config", "--images")
+	if err != nil {
+		return nil
+	}
+	return strings.Fields(string(out))
+}
@@ -0,0 +1,164 @@
+package installer
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestParseComposeProject(t *testing.T) {
+	data := []byte(`{
+  "name": "dify",
+  "services": {"api": {"image": "langgenius/dify-api"}},
+  "volumes": {
+    "db": {},
+    "cache": {"name": "dify-cache"},
+    "models": {"name": "shared-models", "external": true}
+  }
+}`)
+	name, volumes, err := parseComposeProject(data)
+	if err != nil {
+		t.Fatalf("parseComposeProject() error = %v", err)
+	}
+	if name != "dify" {
+		t.Errorf("name = %q, want %q", name, "dify")
+	}
+	want := map[string]string{"db": "dify_db", "cache": "dify-cache"}
+	if len(volumes) != len(want) {
+		t.Fatalf("volumes = %v, want %v", volumes, want)
+	}
+	for key, v := range want {
+		if volumes[key] != v {
+			t.Errorf("volumes[%q] = %q, want %q", key, volumes[key], v)
+		}
+	}
+
+	if _, _, err := parseComposeProject([]byte("services: {}")); err == nil {
+		t.Error("parseComposeProject() accepted YAML output")
+	}
+}
+
+func writeTestArchive(t *testing.T, entries map[string]string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "backup.tar.gz")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for _, name := range sortedKeys(entries) {
+		if err := addTarEntry(tw, name, int64(len(entries[name])), strings.NewReader(entries[name])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tw.Close()
+	gz.Close()
+	f.Close()
+	return path
+}
+
+func TestReadBackupManifest(t *testing.T) {
+	path := writeTestArchive(t, map[string]string{
+		"manifest.json": `{"tool": "open-webui", "kind": "container", "mounts": [{"entry": "mounts/0.tar", "source": "open-webui-data", "target": "/app/backend/data"}]}`,
+		"mounts/0.tar":  "data",
+	})
+	m, err := ReadBackupManifest(path)
+	if err != nil {
+		t.Fatalf("ReadBackupManifest() error = %v", err)
+	}
+	if m.Tool != "open-webui" || m.Kind != "container" {
+		t.Errorf("manifest = %+v", m)
+	}
+	if len(m.Mounts) != 1 || m.Mounts[0].Target != "/app/backend/data" {
+		t.Errorf("mounts = %+v", m.Mounts)
+	}
+
+	var names []string
+	err = walkBackup(path, func(name string, r io.Reader) error {
+		names = append(names, name)
+		return nil
+	})
+	if err != nil || strings.Join(names, ",") != "manifest.json,mounts/0.tar" {
+		t.Errorf("walkBackup() = %v, %v", names, err)
+	}
+
+	noManifest := writeTestArchive(t, map[string]string{"mounts/0.tar": "data"})
+	if _, err := ReadBackupManifest(noManifest); err == nil {
+		t.Error("ReadBackupManifest() accepted an archive without manifest")
+	}
+
+	plain := filepath.Join(t.TempDir(), "plain.txt")
+	os.WriteFile(plain, []byte("not gzip"), 0600)
+	if _, err := ReadBackupManifest(plain); err == nil {
+		t.Error("ReadBackupManifest() accepted a plain file")
+	}
+}
+
+func TestParseComposeContainerNames(t *testing.T) {
+	data := []byte(`{"services": {
+  "web": {"container_name": "dify-web"},
+  "api": {"container_name": "dify-api"},
+  "worker": {}
+}}`)
+	if got := strings.Join(parseComposeContainerNames(data), ","); got != "dify-api,dify-web" {
+		t.Errorf("parseComposeContainerNames() = %s, want dify-api,dify-web", got)
+	}
+	if got := parseComposeContainerNames([]byte("services: {}")); got != nil {
+		t.Errorf("parseComposeContainerNames(yaml) = %v, want nil", got)
+	}
+}
+
+func TestRestoreScripts(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the scripts run in a Linux helper container")
+	}
+	dir := t.TempDir()
+	for _, name := range []string{"old.db", ".hidden", "sub/file"} {
+		path := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte("old"), 0644)
+	}
+	run := func(script string, stdin []byte) error {
+		cmd := exec.Command("sh", "-c", script)
+		cmd.Stdin = bytes.NewReader(stdin)
+		return cmd.Run()
+	}
+
+	// A broken archive leaves the data alone
+	if err := run(stageScript(dir), []byte("not a tar")); err == nil {
+		t.Fatal("stageScript() accepted a broken archive")
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "old.db")); string(data) != "old" {
+		t.Fatal("data changed by a failed extraction")
+	}
+
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	for name, body := range map[string]string{"new.db": "new", ".env": "KEY=1"} {
+		tw.WriteHeader(&tar.Header{Name: "./" + name, Mode: 0644, Size: int64(len(body)), Typeflag: tar.TypeReg})
+		tw.Write([]byte(body))
+	}
+	tw.Close()
+	if err := run(stageScript(dir), archive.Bytes()); err != nil {
+		t.Fatalf("stageScript() error = %v", err)
+	}
+	if err := run(swapScript(dir), nil); err != nil {
+		t.Fatalf("swapScript() error = %v", err)
+	}
+	entries, _ := os.ReadDir(dir)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	if got := strings.Join(names, ","); got != ".env,new.db" {
+		t.Errorf("after restore = %s, want .env,new.db", got)
+	}
+}
@@ -0,0 +1,138 @@
+package installer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ComposeService is a container exported as a compose service
+type ComposeService struct {
+	Name string // service name, usually the tool name
+	Spec ContainerSpec
+}
+
+// ComposeExternal lists the named volumes and networks an exported compose
+// file uses as they are. Compose neither creates nor removes external
+// ones, so `docker compose down -v` keeps the data of the getoai installs.
+type ComposeExternal struct {
+	Volumes  map[string]bool
+	Networks map[string]bool
+}
+
+// ExistingExternal returns the named volumes and networks of the services
+// that exist on the engine
+func ExistingExternal(services []ComposeService) ComposeExternal {
+	ext := ComposeExternal{Volumes: make(map[string]bool), Networks: make(map[string]bool)}
+	for _, svc := range services {
+		for _, v := range svc.Spec.Volumes {
+			source := VolumeSource(v)
+			if _, seen := ext.Volumes[source]; IsNamedVolume(source) && !seen {
+				_, err := RunCommandSilent(containerCLI(), "volume", "inspect", source)
+				ext.Volumes[source] = err == nil
+			}
+		}
+		if _, seen := ext.Networks[svc.Spec.Network]; svc.Spec.Network != "" && !seen {
+			name := svc.Spec.Network
+			_, err := RunCommandSilent(containerCLI(), "network", "inspect", name)
+			ext.Networks[name] = err == nil
+		}
+	}
+	return ext
+}
+
+// ComposeYAML renders the containers as a docker compose file. Every
+// service keeps its container name and restart policy; named volumes and
+// networks are declared at the top level with their current names, so the
+// file picks up existing data when run on the same machine. The ones in
+// ext are marked external.
+func ComposeYAML(services []ComposeService, ext ComposeExternal) string {
+	var b strings.Builder
+	volumes := make(map[string]bool)
+	networks := make(map[string]bool)
+
+	b.WriteString("services:\n")
+	for _, svc := range services {
+		s := svc.Spec
+		fmt.Fprintf(&b, "  %s:\n", yamlKey(svc.Name))
+		fmt.Fprintf(&b, "    image: %s\n", yamlString(s.Image))
+		fmt.Fprintf(&b, "    container_name: %s\n", yamlString(s.Name))
+		b.WriteString("    restart: unless-stopped\n")
+		writeYAMLList(&b, "ports", s.Ports)
+		if len(s.Env) > 0 {
+			b.WriteString("    environment:\n")
+			for _, k := range sortedKeys(s.Env) {
+				fmt.Fprintf(&b, "      %s: %s\n", yamlKey(k), yamlString(s.Env[k]))
+			}
+		}
+		writeYAMLList(&b, "volumes", s.Volumes)
+		writeYAMLList(&b, "extra_hosts", s.Hosts)
+		if len(s.Labels) > 0 {
+			b.WriteString("    labels:\n")
+			for _, k := range sortedKeys(s.Labels) {
+				fmt.Fprintf(&b, "      %s: %s\n", yamlKey(k), yamlString(s.Labels[k]))
+			}
+		}
+		if s.Network != "" {
+			writeYAMLList(&b, "networks", []string{s.Network})
+			networks[s.Network] = true
+		}
+
+		for _, v := range s.Volumes {
+			if source := VolumeSource(v); IsNamedVolume(source) {
+				volumes[source] = true
+			}
+		}
+	}
+
+	if len(volumes) > 0 {
+		b.WriteString("\nvolumes:\n")
+		for _, name := range sortedKeys(volumes) {
+			writeYAMLResource(&b, name, ext.Volumes[name])
+		}
+	}
+	if len(networks) > 0 {
+		b.WriteString("\nnetworks:\n")
+		for _, name := range sortedKeys(networks) {
+			writeYAMLResource(&b, name, ext.Networks[name])
+		}
+	}
+	return b.String()
+}
+
+// writeYAMLResource declares a top-level volume or network
+func writeYAMLResource(b *strings.Builder, name string, external bool) {
+	fmt.Fprintf(b, "  %s:\n    name: %s\n", yamlKey(name), yamlString(name))
+	if external {
+		b.WriteString("    external: true\n")
+	}
+}
+
+func writeYAMLList(b *strings.Builder, key string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "    %s:\n", key)
+	for _, item := range items {
+		fmt.Fprintf(b, "      - %s\n", yamlString(item))
+	}
+}
+
+// yamlString quotes a value for YAML. Dollar signs are doubled, otherwise
+// compose would read them as variable references.
+func yamlString(s string) string {
+	return strconv.Quote(strings.ReplaceAll(s, "$", "$$"))
+}
+
+// yamlKey returns a mapping key, quoted unless it is a plain word
+func yamlKey(s string) string {
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-' || r == '.') {
+			return strconv.Quote(s)
+		}
+	}
+	if s == "" {
+		return `""`
+	}
+	return s
+}
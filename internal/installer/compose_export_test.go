@@ -0,0 +1,75 @@
+package installer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestComposeYAML(t *testing.T) {
+	services := []ComposeService{
+		{Name: "open-webui", Spec: ContainerSpec{
+			Name:    "open-webui",
+			Image:   "ghcr.io/open-webui/open-webui:main",
+			Ports:   []string{"3000:8080"},
+			Env:     map[string]string{"WEBUI_SECRET_KEY": `a$b"c`, "OLLAMA_BASE_URL": "http://ollama:11434"},
+			Volumes: []string{"open-webui-data:/app/backend/data", "./uploads:/uploads:ro"},
+			Network: NetworkName,
+		}},
+		{Name: "lobechat", Spec: ContainerSpec{
+			Name:    "my-chat",
+			Image:   "lobehub/lobe-chat",
+			Ports:   []string{"3210:3210"},
+			Labels:  map[string]string{"com.example.team": "ml"},
+			Hosts:   []string{HostGateway},
+			Network: NetworkName,
+		}},
+	}
+
+	want := `services:
+  open-webui:
+    image: "ghcr.io/open-webui/open-webui:main"
+    container_name: "open-webui"
+    restart: unless-stopped
+    ports:
+      - "3000:8080"
+    environment:
+      OLLAMA_BASE_URL: "http://ollama:11434"
+      WEBUI_SECRET_KEY: "a$$b\"c"
+    volumes:
+      - "open-webui-data:/app/backend/data"
+      - "./uploads:/uploads:ro"
+    networks:
+      - "getoai"
+  lobechat:
+    image: "lobehub/lobe-chat"
+    container_name: "my-chat"
+    restart: unless-stopped
+    ports:
+      - "3210:3210"
+    extra_hosts:
+      - "host.docker.internal:host-gateway"
+    labels:
+      com.example.team: "ml"
+    networks:
+      - "getoai"
+
+volumes:
+  open-webui-data:
+    name: "open-webui-data"
+
+networks:
+  getoai:
+    name: "getoai"
+`
+	if got := ComposeYAML(services, ComposeExternal{}); got != want {
+		t.Errorf("ComposeYAML() =\n%s\nwant\n%s", got, want)
+	}
+
+	ext := ComposeExternal{Volumes: map[string]bool{"open-webui-data": true}, Networks: map[string]bool{NetworkName: true}}
+	got := ComposeYAML(services, ext)
+	for _, external := range []string{"  open-webui-data:\n    name: \"open-webui-data\"\n    external: true\n", "  getoai:\n    name: \"getoai\"\n    external: true\n"} {
+		if !strings.Contains(got, external) {
+			t.Errorf("ComposeYAML() doesn't mark external:\n%s\nin\n%s", external, got)
+		}
+	}
+}
@@ -0,0 +1,358 @@
+package installer
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ComposeSource pins the part of a git repository that holds a compose
+// app, so installs fetch only that directory at a known version
+type ComposeSource struct {
+	Repo string `json:"repo"`           // git repository URL
+	Ref  string `json:"ref,omitempty"`  // tag or branch, the default branch if empty
+	Path string `json:"path,omitempty"` // directory holding the compose file, the whole repository if empty
+	File string `json:"file,omitempty"` // compose file in Path, found by its usual names if empty
+}
+
+// sourceFile records the fetched ComposeSource inside the install directory
+const sourceFile = ".getoai-source.json"
+
+// filesList records the files fetched from the source, one slash-separated
+// path per line, so updates can remove the ones dropped upstream
+const filesList = ".getoai-files"
+
+// RefName returns the ref for display
+func (s ComposeSource) RefName() string {
+	if s.Ref == "" {
+		return "default branch"
+	}
+	return s.Ref
+}
+
+// ReadComposeSource returns the source an app in installDir was fetched
+// from, or nil for apps installed as a full git clone by older versions
+func ReadComposeSource(installDir string) *ComposeSource {
+	data, err := os.ReadFile(filepath.Join(installDir, sourceFile))
+	if err != nil {
+		return nil
+	}
+	var src ComposeSource
+	if json.Unmarshal(data, &src) != nil {
+		return nil
+	}
+	return &src
+}
+
+func writeComposeSource(installDir string, src ComposeSource) error {
+	data, err := json.MarshalIndent(src, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(installDir, sourceFile), data, 0644); err != nil {
+		return err
+	}
+	return writeFilesList(installDir)
+}
+
+// writeFilesList records the regular files under dir in its filesList
+func writeFilesList(dir string) error {
+	var files []string
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if rel = filepath.ToSlash(rel); rel != sourceFile {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, filesList), []byte(strings.Join(files, "\n")+"\n"), 0644)
+}
+
+// readFilesList returns the files recorded in dir's filesList, nil for
+// apps fetched before the list was kept
+func readFilesList(dir string) []string {
+	data, err := os.ReadFile(filepath.Join(dir, filesList))
+	if err != nil {
+		return nil
+	}
+	return strings.Fields(string(data))
+}
+
+// localFile reports whether a file belongs to the user rather than the
+// upstream app: .env and compose override files are never removed
+func localFile(rel string) bool {
+	name := path.Base(rel)
+	return name == ".env" || strings.Contains(name, ".override.")
+}
+
+// Fetch downloads the pinned directory into dir, keeping its path inside
+// the repository. It uses a shallow sparse git checkout, falling back to
+// the tarball of GitHub repositories when git is missing or fails.
+func (s ComposeSource) Fetch(dir string) error {
+	gitErr := s.fetchGit(dir)
+	if gitErr == nil {
+		return writeComposeSource(dir, s)
+	}
+	_ = os.RemoveAll(dir)
+
+	url := githubTarballURL(s.Repo, s.Ref)
+	if url == "" {
+		return gitErr
+	}
+	fmt.Printf("git checkout failed (%v), downloading %s...\n", gitErr, url)
+	if err := downloadTarball(url, s.Path, dir); err != nil {
+		_ = os.RemoveAll(dir)
+		return err
+	}
+	return writeComposeSource(dir, s)
+}
+
+func (s ComposeSource) fetchGit(dir string) error {
+	if _, err := exec.LookPath("git"); err != nil {
+		return fmt.Errorf("git is not installed")
+	}
+
+	args := []string{"clone", "--quiet", "--depth", "1", "--filter=blob:none"}
+	if s.Path != "" {
+		args = append(args, "--no-checkout")
+	}
+	if s.Ref != "" {
+		args = append(args, "--branch", s.Ref)
+	}
+	args = append(args, s.Repo, dir)
+	if out, err := RunCommandSilent("git", args...); err != nil {
+		return fmt.Errorf("git clone: %s", strings.TrimSpace(out))
+	}
+
+	if s.Path != "" {
+		if out, err := RunCommandSilent("git", "-C", dir, "sparse-checkout", "set", s.Path); err != nil {
+			return fmt.Errorf("git sparse-checkout: %s", strings.TrimSpace(out))
+		}
+		if out, err := RunCommandSilent("git", "-C", dir, "checkout", "--quiet"); err != nil {
+			return fmt.Errorf("git checkout: %s", strings.TrimSpace(out))
+		}
+	}
+
+	// Keep a plain snapshot; updates fetch the next ref side by side
+	return os.RemoveAll(filepath.Join(dir, ".git"))
+}
+
+// githubTarballURL returns the archive URL of a GitHub repository at ref,
+// or an empty string for other hosts
+func githubTarballURL(repo, ref string) string {
+	rest, ok := strings.CutPrefix(repo, "https://github.com/")
+	if !ok {
+		return ""
+	}
+	rest = strings.TrimSuffix(strings.TrimSuffix(rest, "/"), ".git")
+	if strings.Count(rest, "/") != 1 {
+		return ""
+	}
+	if ref == "" {
+		ref = "HEAD"
+	}
+	return fmt.Sprintf("https://github.com/%s/archive/%s.tar.gz", rest, ref)
+}
+
+func downloadTarball(url, subPath, dir string) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+	return extractTarball(resp.Body, subPath, dir)
+}
+
+// extractTarball unpacks the files under subPath of a GitHub archive into
+// dir, dropping the archive's top-level directory
+func extractTarball(r io.Reader, subPath, dir string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("invalid archive: %w", err)
+	}
+	defer gz.Close()
+
+	prefix := strings.Trim(subPath, "/")
+	found := false
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("invalid archive: %w", err)
+		}
+
+		// "repo-v1.0/docker/compose.yaml" -> "docker/compose.yaml"
+		_, name, _ := strings.Cut(path.Clean(hdr.Name), "/")
+		if name == "" || name == "." || strings.HasPrefix(name, "../") {
+			continue
+		}
+		if prefix != "" && name != prefix && !strings.HasPrefix(name, prefix+"/") {
+			continue
+		}
+		found = true
+		target := filepath.Join(dir, filepath.FromSlash(name))
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(hdr.Mode).Perm()|0600)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			f.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if !found {
+		return fmt.Errorf("%s not found in archive", subPath)
+	}
+	return nil
+}
+
+// ComposeChange is the diff of one file between two versions of a compose app
+type ComposeChange struct {
+	File string   // path relative to the install directory
+	Diff []string // see LineDiff
+}
+
+// UpdateCompose fetches src next to the compose app in installDir and hands
+// the changes to its compose file and .env.example to review. If review
+// accepts them, the new files are copied over the app, keeping .env and the
+// data written by its containers. It reports whether the update was applied.
+func (d *DockerInstaller) UpdateCompose(installDir string, src ComposeSource, review func([]ComposeChange) bool) (bool, error) {
+	staging := installDir + ".update"
+	_ = os.RemoveAll(staging)
+	defer os.RemoveAll(staging)
+
+	fmt.Printf("Fetching %s (%s)...\n", src.Repo, src.RefName())
+	if err := src.Fetch(staging); err != nil {
+		return false, fmt.Errorf("failed to fetch %s: %w", src.RefName(), err)
+	}
+	newCompose := FindComposeFile(staging)
+	if newCompose == "" {
+		return false, fmt.Errorf("no docker-compose file found in %s at %s", src.Repo, src.RefName())
+	}
+
+	changes, err := composeChanges(installDir, staging, FindComposeFile(installDir), newCompose)
+	if err != nil {
+		return false, err
+	}
+	if !review(changes) {
+		return false, nil
+	}
+
+	if err := copyTree(staging, installDir); err != nil {
+		return false, fmt.Errorf("failed to update %s: %w", installDir, err)
+	}
+
+	// Settings added upstream have to be copied into .env by hand
+	composeDir := filepath.Dir(FindComposeFile(installDir))
+	example, _ := ReadEnvFile(filepath.Join(composeDir, ".env.example"))
+	env, _ := ReadEnvFile(filepath.Join(composeDir, ".env"))
+	var added []string
+	for _, key := range sortedKeys(example) {
+		if _, ok := env[key]; !ok {
+			added = append(added, key)
+		}
+	}
+	if len(env) > 0 && len(added) > 0 {
+		fmt.Printf("\033[33m!\033[0m New settings in .env.example, not set in .env: %s\n", strings.Join(added, ", "))
+	}
+	return true, nil
+}
+
+// composeChanges diffs the compose file and .env.example of the installed
+// app against the fetched one
+func composeChanges(oldDir, newDir, oldCompose, newCompose string) ([]ComposeChange, error) {
+	relCompose, err := filepath.Rel(newDir, newCompose)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{relCompose, filepath.Join(filepath.Dir(relCompose), ".env.example")}
+	if oldCompose != "" {
+		if rel, err := filepath.Rel(oldDir, oldCompose); err == nil && rel != relCompose {
+			files = append([]string{rel}, files...)
+		}
+	}
+
+	var changes []ComposeChange
+	for _, file := range files {
+		before, _ := os.ReadFile(filepath.Join(oldDir, file))
+		after, _ := os.ReadFile(filepath.Join(newDir, file))
+		if diff := LineDiff(string(before), string(after)); diff != nil {
+			changes = append(changes, ComposeChange{File: filepath.ToSlash(file), Diff: diff})
+		}
+	}
+	return changes, nil
+}
+
+// copyTree copies the files under src into dst, overwriting files with the
+// same path. Files fetched into dst earlier that src no longer has are
+// removed, except local ones; other files in dst, such as data written by
+// containers, are left alone.
+func copyTree(src, dst string) error {
+	for _, rel := range readFilesList(dst) {
+		if localFile(rel) {
+			continue
+		}
+		if _, err := os.Lstat(filepath.Join(src, filepath.FromSlash(rel))); os.IsNotExist(err) {
+			if err := os.Remove(filepath.Join(dst, filepath.FromSlash(rel))); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+
+	return filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, info.Mode().Perm())
+	})
+}
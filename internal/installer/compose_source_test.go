@@ -0,0 +1,153 @@
+package installer
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGithubTarballURL(t *testing.T) {
+	tests := []struct {
+		repo, ref, want string
+	}{
+		{"https://github.com/langgenius/dify", "1.0.0", "https://github.com/langgenius/dify/archive/1.0.0.tar.gz"},
+		{"https://github.com/langgenius/dify.git", "", "https://github.com/langgenius/dify/archive/HEAD.tar.gz"},
+		{"https://github.com/langgenius/dify/", "main", "https://github.com/langgenius/dify/archive/main.tar.gz"},
+		{"https://gitlab.com/group/project", "v1", ""},
+		{"https://github.com/langgenius", "", ""},
+	}
+	for _, tt := range tests {
+		if got := githubTarballURL(tt.repo, tt.ref); got != tt.want {
+			t.Errorf("githubTarballURL(%q, %q) = %q, want %q", tt.repo, tt.ref, got, tt.want)
+		}
+	}
+}
+
+func makeTarball(t *testing.T, files map[string]string) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, name := range sortedKeys(files) {
+		body := files[name]
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(body)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tw.Close()
+	gz.Close()
+	return &buf
+}
+
+func TestExtractTarball(t *testing.T) {
+	archive := map[string]string{
+		"dify-1.0.0/README.md":                  "readme",
+		"dify-1.0.0/docker/docker-compose.yaml": "services: {}",
+		"dify-1.0.0/docker/nginx/nginx.conf":    "server {}",
+		"dify-1.0.0/dockerfiles/x":              "not under docker/",
+	}
+	dir := t.TempDir()
+	if err := extractTarball(makeTarball(t, archive), "docker", dir); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"docker/docker-compose.yaml", "docker/nginx/nginx.conf"} {
+		if _, err := os.Stat(filepath.Join(dir, want)); err != nil {
+			t.Errorf("%s not extracted", want)
+		}
+	}
+	for _, skipped := range []string{"README.md", "dockerfiles/x"} {
+		if _, err := os.Stat(filepath.Join(dir, skipped)); err == nil {
+			t.Errorf("%s extracted outside the sub-path", skipped)
+		}
+	}
+
+	if err := extractTarball(makeTarball(t, archive), "deploy", t.TempDir()); err == nil {
+		t.Error("missing sub-path: expected an error")
+	}
+}
+
+func TestComposeChanges(t *testing.T) {
+	oldDir, newDir := t.TempDir(), t.TempDir()
+	write := func(dir, name, body string) {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(oldDir, "docker/docker-compose.yaml", "image: app:1.0\n")
+	write(oldDir, "docker/.env.example", "PORT=80\n")
+	write(oldDir, "docker/.env", "PORT=8080\n")
+	write(newDir, "docker/docker-compose.yaml", "image: app:1.1\n")
+	write(newDir, "docker/.env.example", "PORT=80\n")
+
+	changes, err := composeChanges(oldDir, newDir,
+		filepath.Join(oldDir, "docker", "docker-compose.yaml"), filepath.Join(newDir, "docker", "docker-compose.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].File != "docker/docker-compose.yaml" {
+		t.Fatalf("changes = %+v, want only the compose file", changes)
+	}
+
+	if err := copyTree(newDir, oldDir); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(oldDir, "docker", "docker-compose.yaml")); string(data) != "image: app:1.1\n" {
+		t.Errorf("compose file not updated: %q", data)
+	}
+	if data, _ := os.ReadFile(filepath.Join(oldDir, "docker", ".env")); string(data) != "PORT=8080\n" {
+		t.Errorf(".env was touched: %q", data)
+	}
+}
+
+func TestCopyTreeRemovesDroppedFiles(t *testing.T) {
+	oldDir, newDir := t.TempDir(), t.TempDir()
+	write := func(dir, name string) {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"docker/docker-compose.yaml", "docker/nginx/old.conf", "docker/.env", "docker/docker-compose.override.yaml"} {
+		write(oldDir, name)
+	}
+	if err := writeFilesList(oldDir); err != nil {
+		t.Fatal(err)
+	}
+	// Written after the fetch, e.g. by a container
+	write(oldDir, "docker/volumes/db/data")
+
+	write(newDir, "docker/docker-compose.yaml")
+	write(newDir, "docker/nginx/new.conf")
+	if err := writeFilesList(newDir); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := copyTree(newDir, oldDir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(oldDir, "docker", "nginx", "old.conf")); !os.IsNotExist(err) {
+		t.Error("file dropped upstream was kept")
+	}
+	for _, kept := range []string{"docker/nginx/new.conf", "docker/.env", "docker/docker-compose.override.yaml", "docker/volumes/db/data"} {
+		if _, err := os.Stat(filepath.Join(oldDir, filepath.FromSlash(kept))); err != nil {
+			t.Errorf("%s was removed", kept)
+		}
+	}
+	if files := readFilesList(oldDir); len(files) != 2 {
+		t.Errorf("files list = %v, want the fetched files", files)
+	}
+}
@@ -0,0 +1,174 @@
+package installer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ContainerSpec describes how a tool's container is run. It is saved in the
+// install receipt so the container can be recreated with the same settings.
+type ContainerSpec struct {
+	Name    string            `json:"name"`
+	Image   string            `json:"image"`
+	Ports   []string          `json:"ports,omitempty"`   // "host:container" mappings
+	Env     map[string]string `json:"env,omitempty"`     // environment variables
+	Volumes []string          `json:"volumes,omitempty"` // "source:target[:options]" mappings
+	Labels  map[string]string `json:"labels,omitempty"`  // container labels
+	Network string            `json:"network,omitempty"` // user-defined network to join
+	Hosts   []string          `json:"hosts,omitempty"`   // extra "host:ip" entries for /etc/hosts
+}
+
+// RunArgs returns the `docker run` arguments that create the container
+func (s ContainerSpec) RunArgs() []string {
+	args := []string{"run", "-d", "--name", s.Name, "--restart", "unless-stopped"}
+
+	// Add port mappings
+	for _, port := range s.Ports {
+		args = append(args, "-p", port)
+	}
+
+	// Add environment variables, sorted so the command is reproducible
+	for _, k := range sortedKeys(s.Env) {
+		args = append(args, "-e", fmt.Sprintf("%s=%s", k, s.Env[k]))
+	}
+
+	// Add volume mappings
+	for _, vol := range s.Volumes {
+		args = append(args, "-v", vol)
+	}
+
+	// Add labels
+	for _, k := range sortedKeys(s.Labels) {
+		args = append(args, "--label", fmt.Sprintf("%s=%s", k, s.Labels[k]))
+	}
+
+	// Add network settings
+	if s.Network != "" {
+		args = append(args, "--network", s.Network)
+	}
+	for _, host := range s.Hosts {
+		args = append(args, "--add-host", host)
+	}
+
+	return append(args, s.Image)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// RunContainer creates and starts the container, replacing an existing
+// container with the same name. The image is pulled by docker if missing.
+func (d *DockerInstaller) RunContainer(spec ContainerSpec) error {
+	// Check if container already exists
+	out, _ := d.RunCommandSilent(containerCLI(), "ps", "-a", "--filter", fmt.Sprintf("name=^%s$", spec.Name), "--format", "{{.Names}}")
+	if strings.TrimSpace(out) == spec.Name {
+		fmt.Printf("Container '%s' already exists. Removing...\n", spec.Name)
+		_, _ = d.RunCommandSilent(containerCLI(), "rm", "-f", spec.Name)
+	}
+	if spec.Network != "" {
+		if err := EnsureNetwork(spec.Network); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Starting container '%s'...\n", spec.Name)
+	if err := d.RunCommand(containerCLI(), spec.RunArgs()...); err != nil {
+		return fmt.Errorf("failed to start container: %w", err)
+	}
+	return nil
+}
+
+// RecreateContainer replaces the container named spec.Name with a new one
+// from spec. The old container is only stopped and set aside until the new
+// one is running; if that fails, it is put back and started again.
+func (d *DockerInstaller) RecreateContainer(spec ContainerSpec) error {
+	old := spec.Name + "-replaced"
+	if ContainerState(spec.Name) != "" {
+		_, _ = d.RunCommandSilent(containerCLI(), "rm", "-f", old)
+		if _, err := d.RunCommandSilent(containerCLI(), "stop", spec.Name); err != nil {
+			return fmt.Errorf("failed to stop %s: %w", spec.Name, err)
+		}
+		if _, err := d.RunCommandSilent(containerCLI(), "rename", spec.Name, old); err != nil {
+			_, _ = d.RunCommandSilent(containerCLI(), "start", spec.Name)
+			return fmt.Errorf("failed to set %s aside: %w", spec.Name, err)
+		}
+	} else {
+		old = ""
+	}
+
+	if err := d.RunContainer(spec); err != nil {
+		if old != "" {
+			_, _ = d.RunCommandSilent(containerCLI(), "rm", "-f", spec.Name)
+			if _, rerr := d.RunCommandSilent(containerCLI(), "rename", old, spec.Name); rerr == nil {
+				if _, serr := d.RunCommandSilent(containerCLI(), "start", spec.Name); serr == nil {
+					fmt.Printf("Container '%s' was started again\n", spec.Name)
+				}
+			}
+		}
+		return err
+	}
+	if old != "" {
+		_ = ForceRemoveContainer(old)
+	}
+	return nil
+}
+
+// ForceRemoveContainer removes a container, stopping it first if needed
+func ForceRemoveContainer(containerName string) error {
+	_, err := RunCommandSilent(containerCLI(), "rm", "-f", containerName)
+	return err
+}
+
+// HostPort returns the host side of a port mapping such as "3000:8080" or
+// "127.0.0.1:3000:8080/tcp"
+func HostPort(mapping string) string {
+	parts := strings.Split(strings.Split(mapping, "/")[0], ":")
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	return parts[len(parts)-2]
+}
+
+// ContainerPort returns the container side of a port mapping, including
+// the protocol if one is given ("8080", "53/udp")
+func ContainerPort(mapping string) string {
+	parts := strings.Split(mapping, ":")
+	return parts[len(parts)-1]
+}
+
+// VolumeTarget returns the path inside the container of a volume mapping
+// such as "data:/app/data:ro". Windows host paths ("C:\data:/app") are
+// handled by taking the last absolute container path.
+func VolumeTarget(mapping string) string {
+	parts := strings.Split(mapping, ":")
+	for i := len(parts) - 1; i > 0; i-- {
+		if strings.HasPrefix(parts[i], "/") {
+			return parts[i]
+		}
+	}
+	return mapping
+}
+
+// VolumeSource returns the host side of a volume mapping: a volume name or
+// a host path
+func VolumeSource(mapping string) string {
+	target := VolumeTarget(mapping)
+	i := strings.LastIndex(mapping, ":"+target)
+	if i < 0 {
+		return ""
+	}
+	return mapping[:i]
+}
+
+// IsNamedVolume reports whether a volume source is a named volume rather
+// than a host path
+func IsNamedVolume(source string) bool {
+	return source != "" && !strings.ContainsAny(source, `/\`) && !strings.HasPrefix(source, ".") && !strings.HasPrefix(source, "~")
+}
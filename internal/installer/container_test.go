@@ -0,0 +1,162 @@
+package installer
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestPortMappingParts(t *testing.T) {
+	tests := []struct {
+		mapping       string
+		hostPort      string
+		containerPort string
+	}{
+		{mapping: "3000:8080", hostPort: "3000", containerPort: "8080"},
+		{mapping: "127.0.0.1:3000:8080", hostPort: "3000", containerPort: "8080"},
+		{mapping: "5353:53/udp", hostPort: "5353", containerPort: "53/udp"},
+		{mapping: "8080", hostPort: "8080", containerPort: "8080"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mapping, func(t *testing.T) {
+			if got := HostPort(tt.mapping); got != tt.hostPort {
+				t.Errorf("HostPort(%q) = %q, want %q", tt.mapping, got, tt.hostPort)
+			}
+			if got := ContainerPort(tt.mapping); got != tt.containerPort {
+				t.Errorf("ContainerPort(%q) = %q, want %q", tt.mapping, got, tt.containerPort)
+			}
+		})
+	}
+}
+
+func TestVolumeTarget(t *testing.T) {
+	tests := []struct {
+		mapping string
+		want    string
+	}{
+		{mapping: "open-webui:/app/backend/data", want: "/app/backend/data"},
+		{mapping: "./data:/data:ro", want: "/data"},
+		{mapping: `C:\Users\me\data:/data`, want: "/data"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mapping, func(t *testing.T) {
+			if got := VolumeTarget(tt.mapping); got != tt.want {
+				t.Errorf("VolumeTarget(%q) = %q, want %q", tt.mapping, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVolumeSource(t *testing.T) {
+	tests := []struct {
+		mapping string
+		source  string
+		named   bool
+	}{
+		{mapping: "open-webui:/app/backend/data", source: "open-webui", named: true},
+		{mapping: "./data:/data:ro", source: "./data"},
+		{mapping: "/srv/models:/models", source: "/srv/models"},
+		{mapping: `C:\Users\me\data:/data`, source: `C:\Users\me\data`},
+		{mapping: "/cache", source: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mapping, func(t *testing.T) {
+			source := VolumeSource(tt.mapping)
+			if source != tt.source {
+				t.Errorf("VolumeSource(%q) = %q, want %q", tt.mapping, source, tt.source)
+			}
+			if got := IsNamedVolume(source); got != tt.named {
+				t.Errorf("IsNamedVolume(%q) = %v, want %v", source, got, tt.named)
+			}
+		})
+	}
+}
+
+func TestContainerSpecRunArgs(t *testing.T) {
+	spec := ContainerSpec{
+		Name:    "web",
+		Image:   "example/web:latest",
+		Ports:   []string{"3001:8080"},
+		Env:     map[string]string{"B": "2", "A": "1"},
+		Volumes: []string{"web:/data"},
+	}
+	want := "run -d --name web --restart unless-stopped -p 3001:8080 -e A=1 -e B=2 -v web:/data example/web:latest"
+	if got := strings.Join(spec.RunArgs(), " "); got != want {
+		t.Errorf("RunArgs() = %q, want %q", got, want)
+	}
+}
+
+func TestContainerSpecRunArgsNetwork(t *testing.T) {
+	spec := ContainerSpec{
+		Name:    "open-webui",
+		Image:   "ghcr.io/open-webui/open-webui:main",
+		Env:     map[string]string{"OLLAMA_BASE_URL": "http://host.docker.internal:11434"},
+		Network: NetworkName,
+		Hosts:   []string{HostGateway},
+	}
+	want := "run -d --name open-webui --restart unless-stopped -e OLLAMA_BASE_URL=http://host.docker.internal:11434 --network getoai --add-host host.docker.internal:host-gateway ghcr.io/open-webui/open-webui:main"
+	if got := strings.Join(spec.RunArgs(), " "); got != want {
+		t.Errorf("RunArgs() = %q, want %q", got, want)
+	}
+}
+
+func TestSpecFromInspect(t *testing.T) {
+	containerJSON := `{
+		"Name": "/open-webui",
+		"Image": "sha256:abc",
+		"Config": {
+			"Image": "ghcr.io/open-webui/open-webui:main",
+			"Env": ["PATH=/usr/local/bin:/usr/bin", "WEBUI_AUTH=False", "PORT=8080"],
+			"Labels": {"org.opencontainers.image.version": "0.5", "com.example.team": "ml"}
+		},
+		"HostConfig": {
+			"Binds": ["open-webui:/app/backend/data"],
+			"NetworkMode": "getoai",
+			"ExtraHosts": ["host.docker.internal:host-gateway"],
+			"PortBindings": {
+				"8080/tcp": [{"HostIp": "", "HostPort": "3001"}],
+				"9090/udp": [{"HostIp": "127.0.0.1", "HostPort": "9090"}]
+			}
+		},
+		"Mounts": [
+			{"Type": "volume", "Name": "open-webui", "Destination": "/app/backend/data"},
+			{"Type": "volume", "Name": "4f1e2d", "Destination": "/app/cache"}
+		]
+	}`
+	imageJSON := `{"Config": {
+		"Env": ["PATH=/usr/local/bin:/usr/bin", "PORT=8080"],
+		"Labels": {"org.opencontainers.image.version": "0.5"}
+	}}`
+
+	var c containerInspect
+	var image imageInspect
+	if err := json.Unmarshal([]byte(containerJSON), &c); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(imageJSON), &image); err != nil {
+		t.Fatal(err)
+	}
+
+	spec := specFromInspect(c, image)
+	if spec.Name != "open-webui" {
+		t.Errorf("Name = %q", spec.Name)
+	}
+	if got := strings.Join(spec.Ports, ","); got != "3001:8080,127.0.0.1:9090:9090/udp" {
+		t.Errorf("Ports = %s", got)
+	}
+	if len(spec.Env) != 1 || spec.Env["WEBUI_AUTH"] != "False" {
+		t.Errorf("Env = %v, want only the container's own variables", spec.Env)
+	}
+	if len(spec.Labels) != 1 || spec.Labels["com.example.team"] != "ml" {
+		t.Errorf("Labels = %v, want only the container's own labels", spec.Labels)
+	}
+	if spec.Network != "getoai" || len(spec.Hosts) != 1 || spec.Hosts[0] != HostGateway {
+		t.Errorf("Network = %q, Hosts = %v", spec.Network, spec.Hosts)
+	}
+	if got := strings.Join(spec.Volumes, ","); got != "open-webui:/app/backend/data,4f1e2d:/app/cache" {
+		t.Errorf("Volumes = %s, want anonymous volumes kept", got)
+	}
+}
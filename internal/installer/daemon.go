@@ -0,0 +1,231 @@
+package installer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// mirrorCheckTimeout bounds the request checking that a mirror responds
+const mirrorCheckTimeout = 10 * time.Second
+
+// SuggestedMirrors are the public Docker Hub mirrors offered after a
+// failed pull
+var SuggestedMirrors = []string{"https://docker.1ms.run", "https://docker.xuanyuan.me"}
+
+// DaemonConfigPath returns the daemon.json of the Docker engine in use:
+// /etc/docker for the system daemon on Linux, the user's config dir for
+// rootless Docker, and ~/.docker for Docker Desktop
+func DaemonConfigPath() string {
+	home, _ := os.UserHomeDir()
+	if runtime.GOOS != "linux" {
+		return filepath.Join(home, ".docker", "daemon.json")
+	}
+	if DockerRootless() {
+		dir := os.Getenv("XDG_CONFIG_HOME")
+		if dir == "" {
+			dir = filepath.Join(home, ".config")
+		}
+		return filepath.Join(dir, "docker", "daemon.json")
+	}
+	return "/etc/docker/daemon.json"
+}
+
+// DockerRootless reports whether the Docker daemon runs in rootless mode
+func DockerRootless() bool {
+	out, err := RunCommandSilent("docker", "info", "-f", "{{.SecurityOptions}}")
+	return err == nil && strings.Contains(out, "rootless")
+}
+
+// NormalizeMirror checks a registry mirror URL, adding https:// when the
+// scheme is missing and dropping a trailing slash
+func NormalizeMirror(mirror string) (string, error) {
+	mirror = strings.TrimSpace(mirror)
+	if !strings.Contains(mirror, "://") {
+		mirror = "https://" + mirror
+	}
+	u, err := url.Parse(mirror)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return "", fmt.Errorf("invalid mirror URL %q", mirror)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("invalid mirror URL %q: no query or fragment allowed", mirror)
+	}
+	return strings.TrimRight(u.String(), "/"), nil
+}
+
+// CheckMirror returns an error unless mirror answers the registry API. A
+// 401 counts: the registry is up and wants a token, as Docker Hub does.
+func CheckMirror(mirror string) error {
+	client := &http.Client{Timeout: mirrorCheckTimeout}
+	resp, err := client.Get(mirror + "/v2/")
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusUnauthorized {
+		return fmt.Errorf("%s/v2/ returned %s", mirror, resp.Status)
+	}
+	return nil
+}
+
+// RegistryMirrors returns the registry mirrors set in a daemon.json
+func RegistryMirrors(data []byte) ([]string, error) {
+	var config struct {
+		Mirrors []string `json:"registry-mirrors"`
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid daemon.json: %w", err)
+	}
+	return config.Mirrors, nil
+}
+
+// MergeRegistryMirrors returns daemon.json with mirrors added ahead of the
+// registry mirrors it already has, so Docker tries them first. Mirrors
+// already set are not repeated, and the other settings are kept as they
+// are.
+func MergeRegistryMirrors(data []byte, mirrors []string) ([]byte, error) {
+	config := make(map[string]json.RawMessage)
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("invalid daemon.json: %w", err)
+		}
+	}
+	existing, err := RegistryMirrors(data)
+	if err != nil {
+		return nil, err
+	}
+	merged := []string{}
+	seen := make(map[string]bool)
+	for _, mirror := range append(append([]string(nil), mirrors...), existing...) {
+		key := mirror
+		if normalized, err := NormalizeMirror(mirror); err == nil {
+			key = normalized
+		}
+		if !seen[key] {
+			seen[key] = true
+			merged = append(merged, mirror)
+		}
+	}
+	value, err := json.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+	config["registry-mirrors"] = value
+	out, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+// ReadDaemonConfig returns the content of daemon.json, empty if it doesn't
+// exist yet
+func ReadDaemonConfig(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// WriteDaemonConfig replaces daemon.json with data, keeping a copy of the
+// current one next to it. It uses sudo when the file belongs to root. The
+// backup path is returned, empty if there was no file to back up.
+func WriteDaemonConfig(path string, data []byte) (string, error) {
+	var backup string
+	if old, err := os.ReadFile(path); err == nil {
+		backup = backupName(path, time.Now())
+		if err := writeFileAsRoot(backup, old); err != nil {
+			return "", fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+	}
+	if err := writeFileAsRoot(path, data); err != nil {
+		return backup, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return backup, nil
+}
+
+// backupName returns a backup path for file that isn't taken yet, so an
+// earlier backup is never overwritten
+func backupName(file string, now time.Time) string {
+	base := fmt.Sprintf("%s.getoai-%s", file, now.Format("20060102-150405"))
+	name := base + ".bak"
+	for i := 2; ; i++ {
+		if _, err := os.Lstat(name); os.IsNotExist(err) {
+			return name
+		}
+		name = fmt.Sprintf("%s-%d.bak", base, i)
+	}
+}
+
+// writeFileAsRoot writes a file, through sudo if the user may not
+func writeFileAsRoot(path string, data []byte) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err == nil {
+		err = os.WriteFile(path, data, 0644)
+	}
+	if err == nil || !os.IsPermission(err) || runtime.GOOS == "windows" {
+		return err
+	}
+
+	tmp, err := os.CreateTemp("", "getoai-daemon-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	tmp.Close()
+	if err := runSudo("mkdir", "-p", filepath.Dir(path)); err != nil {
+		return err
+	}
+	return runSudo("cp", tmp.Name(), path)
+}
+
+// RestartDockerCommand returns the command restarting the Docker daemon
+// on Linux
+func RestartDockerCommand() string {
+	if DockerRootless() {
+		return "systemctl --user restart docker"
+	}
+	return "sudo systemctl restart docker"
+}
+
+// RestartDocker restarts the Docker daemon on Linux so it reads daemon.json
+// again. Docker Desktop has to be restarted from its menu.
+func RestartDocker() error {
+	if runtime.GOOS != "linux" {
+		return fmt.Errorf("restart Docker Desktop to apply the change")
+	}
+	if DockerRootless() {
+		return systemctl("restart", "docker")
+	}
+	return runSudo("systemctl", "restart", "docker")
+}
+
+func runSudo(args ...string) error {
+	if os.Geteuid() != 0 {
+		args = append([]string{"sudo"}, args...)
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %w", strings.Join(args, " "), err)
+	}
+	return nil
+}
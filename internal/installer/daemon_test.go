@@ -0,0 +1,99 @@
+package installer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMergeRegistryMirrors(t *testing.T) {
+	data := []byte(`{"log-driver": "json-file", "log-opts": {"max-size": "10m"}, "registry-mirrors": ["https://old.example"]}`)
+	out, err := MergeRegistryMirrors(data, []string{"https://a.example", "https://b.example"})
+	if err != nil {
+		t.Fatalf("MergeRegistryMirrors() error = %v", err)
+	}
+	var config map[string]any
+	if err := json.Unmarshal(out, &config); err != nil {
+		t.Fatalf("invalid output: %v\n%s", err, out)
+	}
+	if config["log-driver"] != "json-file" || config["log-opts"].(map[string]any)["max-size"] != "10m" {
+		t.Errorf("other settings not kept: %s", out)
+	}
+	mirrors, _ := RegistryMirrors(out)
+	if got := strings.Join(mirrors, ","); got != "https://a.example,https://b.example,https://old.example" {
+		t.Errorf("registry-mirrors = %s", got)
+	}
+
+	again, err := MergeRegistryMirrors(out, []string{"https://old.example", "https://c.example"})
+	if err != nil {
+		t.Fatalf("MergeRegistryMirrors() error = %v", err)
+	}
+	mirrors, _ = RegistryMirrors(again)
+	if got := strings.Join(mirrors, ","); got != "https://old.example,https://c.example,https://a.example,https://b.example" {
+		t.Errorf("registry-mirrors = %s, want the new ones first without repeats", got)
+	}
+
+	if out, err := MergeRegistryMirrors(nil, []string{"https://a.example"}); err != nil || !strings.Contains(string(out), "https://a.example") {
+		t.Errorf("MergeRegistryMirrors(empty) = %s, %v", out, err)
+	}
+	if _, err := MergeRegistryMirrors([]byte(`{"debug": true,}`), nil); err == nil {
+		t.Error("MergeRegistryMirrors() accepted invalid JSON")
+	}
+}
+
+func TestNormalizeMirror(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"https://docker.1ms.run/", "https://docker.1ms.run", false},
+		{"docker.1ms.run", "https://docker.1ms.run", false},
+		{"http://mirror.internal:5000", "http://mirror.internal:5000", false},
+		{"ftp://mirror.example", "", true},
+		{"https://", "", true},
+		{"https://mirror.example/?x=1", "", true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeMirror(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("NormalizeMirror(%q) = %q, %v, want %q, wantErr %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestCheckMirror(t *testing.T) {
+	status := map[string]int{"/up/v2/": http.StatusOK, "/auth/v2/": http.StatusUnauthorized}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if code, ok := status[r.URL.Path]; ok {
+			w.WriteHeader(code)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	for path, wantErr := range map[string]bool{"/up": false, "/auth": false, "/missing": true} {
+		if err := CheckMirror(server.URL + path); (err != nil) != wantErr {
+			t.Errorf("CheckMirror(%s) error = %v, wantErr %v", path, err, wantErr)
+		}
+	}
+}
+
+func TestBackupName(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "daemon.json")
+	now := time.Date(2026, 10, 18, 20, 48, 8, 0, time.UTC)
+	first := backupName(file, now)
+	if first != file+".getoai-20261018-204808.bak" {
+		t.Errorf("backupName() = %s", first)
+	}
+	os.WriteFile(first, nil, 0644)
+	if second := backupName(file, now); second == first || !strings.HasSuffix(second, "-2.bak") {
+		t.Errorf("backupName() = %s, want a new name next to %s", second, first)
+	}
+}
@@ -0,0 +1,86 @@
+package installer
+
+import "strings"
+
+// diffContext is the number of unchanged lines shown around a change
+const diffContext = 2
+
+// LineDiff compares two texts line by line and returns the changes in a
+// unified-diff like form: removed lines start with "-", added lines with
+// "+", and a few unchanged lines around each change with " ". Skipped runs
+// of unchanged lines are shown as "...". Identical texts give nil.
+func LineDiff(a, b string) []string {
+	x, y := splitLines(a), splitLines(b)
+
+	// lcs[i][j] is the length of the longest common subsequence of x[i:]
+	// and y[j:]
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []string
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			ops = append(ops, " "+x[i])
+			i++
+			j++
+		case i < len(x) && (j == len(y) || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, "-"+x[i])
+			i++
+		default:
+			ops = append(ops, "+"+y[j])
+			j++
+		}
+	}
+
+	// Keep the changes and their context
+	keep := make([]bool, len(ops))
+	changed := false
+	for k, op := range ops {
+		if op[0] == ' ' {
+			continue
+		}
+		changed = true
+		for c := max(0, k-diffContext); c <= min(len(ops)-1, k+diffContext); c++ {
+			keep[c] = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	var out []string
+	skipped := false
+	for k, op := range ops {
+		if !keep[k] {
+			skipped = true
+			continue
+		}
+		if skipped && len(out) > 0 {
+			out = append(out, "...")
+		}
+		skipped = false
+		out = append(out, op)
+	}
+	return out
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimSuffix(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
@@ -0,0 +1,36 @@
+package installer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLineDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want []string
+	}{
+		{"identical", "a\nb\n", "a\nb", nil},
+		{"both empty", "", "", nil},
+		{"added file", "", "a\nb\n", []string{"+a", "+b"}},
+		{"changed line", "a\nb\nc\n", "a\nB\nc\n", []string{" a", "-b", "+B", " c"}},
+		{
+			"context is trimmed",
+			"1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			"1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			[]string{" 8", " 9", "+10"},
+		},
+		{
+			"separate changes",
+			"x\n1\n2\n3\n4\n5\n6\ny\n",
+			"X\n1\n2\n3\n4\n5\n6\nY\n",
+			[]string{"-x", "+X", " 1", " 2", "...", " 5", " 6", "-y", "+Y"},
+		},
+	}
+	for _, tt := range tests {
+		if got := LineDiff(tt.a, tt.b); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: LineDiff() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package installer
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ReadEnvFile parses a dotenv file into a map. Comments and blank lines are
+// skipped and surrounding quotes are removed from values. A missing file
+// yields an empty map.
+func ReadEnvFile(path string) (map[string]string, error) {
+	env := make(map[string]string)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return env, nil
+		}
+		return env, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		key, value, ok := parseEnvLine(line)
+		if ok {
+			env[key] = value
+		}
+	}
+	return env, nil
+}
+
+// SetEnvValue sets a variable in a dotenv file, replacing its existing
+// assignment in place or appending it, and leaves the rest of the file
+// (comments, ordering) untouched
+func SetEnvValue(path, key, value string) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	lines := strings.Split(string(data), "\n")
+	replaced := false
+	for i, line := range lines {
+		if k, _, ok := parseEnvLine(line); ok && k == key {
+			lines[i] = key + "=" + value
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		// Keep the trailing newline at the end of the file
+		if n := len(lines); n > 0 && lines[n-1] == "" {
+			lines = append(lines[:n-1], key+"="+value, "")
+		} else {
+			lines = append(lines, key+"="+value)
+		}
+	}
+
+	return writeFileAtomic(path, []byte(strings.Join(lines, "\n")))
+}
+
+// writeFileAtomic replaces a file through a temporary file in the same
+// directory, so it is never left half written, keeping its permissions
+func writeFileAtomic(path string, data []byte) error {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// envKeyPattern matches valid variable names
+var envKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidEnvKey reports whether key can be used as a variable name
+func ValidEnvKey(key string) bool {
+	return envKeyPattern.MatchString(key)
+}
+
+// QuoteEnvValue quotes a value for a dotenv file if it contains spaces,
+// comment markers or quotes, so compose reads it back unchanged
+func QuoteEnvValue(value string) string {
+	if !strings.ContainsAny(value, " \t#\"'$\\") {
+		return value
+	}
+	if !strings.Contains(value, "'") {
+		// Single quotes keep the value literal, including $
+		return "'" + value + "'"
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `$$`)
+	return `"` + r.Replace(value) + `"`
+}
+
+// GenerateSecret returns a random hex string of n bytes
+func GenerateSecret(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// secretRef matches a {KEY} placeholder in a secret reference template
+var secretRef = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// SecretRefs returns the secrets a reference template refers to
+func SecretRefs(template string) []string {
+	var keys []string
+	for _, m := range secretRef.FindAllStringSubmatch(template, -1) {
+		keys = append(keys, m[1])
+	}
+	return keys
+}
+
+// GenerateSecrets sets each key of secrets in the dotenv file to a random
+// hex string of the given number of bytes. Each key of refs is then set to
+// its template with {KEY} replaced by the value generated for KEY, for
+// variables that repeat a secret, e.g. a URL holding a password. The file
+// is made readable by its owner only.
+func GenerateSecrets(path string, secrets map[string]int, refs map[string]string) error {
+	values := make(map[string]string)
+	for _, key := range sortedKeys(secrets) {
+		secret, err := GenerateSecret(secrets[key])
+		if err != nil {
+			return err
+		}
+		if err := SetEnvValue(path, key, secret); err != nil {
+			return err
+		}
+		values[key] = secret
+	}
+	for _, key := range sortedKeys(refs) {
+		var missing string
+		value := secretRef.ReplaceAllStringFunc(refs[key], func(ref string) string {
+			secret, ok := values[ref[1:len(ref)-1]]
+			if !ok {
+				missing = ref
+			}
+			return secret
+		})
+		if missing != "" {
+			return fmt.Errorf("%s refers to %s, which is not a generated secret", key, missing)
+		}
+		if err := SetEnvValue(path, key, value); err != nil {
+			return err
+		}
+	}
+	return os.Chmod(path, 0600)
+}
+
+func parseEnvLine(line string) (key, value string, ok bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", "", false
+	}
+	line = strings.TrimPrefix(line, "export ")
+	key, value, ok = strings.Cut(line, "=")
+	if !ok {
+		return "", "", false
+	}
+	key = strings.TrimSpace(key)
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		quote := value[0]
+		value = value[1 : len(value)-1]
+		if quote == '"' {
+			value = strings.NewReplacer(`\\`, `\`, `\"`, `"`, `$$`, `$`).Replace(value)
+		}
+	}
+	return key, value, key != ""
+}
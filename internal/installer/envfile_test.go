@@ -0,0 +1,118 @@
+package installer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetEnvValue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	initial := "# Ports\nEXPOSE_NGINX_PORT=80\nexport SECRET_KEY=\"abc\"\n"
+	if err := os.WriteFile(path, []byte(initial), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetEnvValue(path, "EXPOSE_NGINX_PORT", "8081"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetEnvValue(path, "NEW_KEY", "value"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := os.ReadFile(path)
+	want := "# Ports\nEXPOSE_NGINX_PORT=8081\nexport SECRET_KEY=\"abc\"\nNEW_KEY=value\n"
+	if string(data) != want {
+		t.Errorf("file = %q, want %q", data, want)
+	}
+
+	env, err := ReadEnvFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if env["SECRET_KEY"] != "abc" || env["EXPOSE_NGINX_PORT"] != "8081" || env["NEW_KEY"] != "value" {
+		t.Errorf("ReadEnvFile() = %v", env)
+	}
+}
+
+func TestQuoteEnvValue(t *testing.T) {
+	tests := []struct {
+		value, want string
+	}{
+		{"plain", "plain"},
+		{"", ""},
+		{"two words", "'two words'"},
+		{"pa$$word", "'pa$$word'"},
+		{"it's #1", `"it's #1"`},
+		{`it's "$x"`, `"it's \"$$x\""`},
+	}
+	for _, tt := range tests {
+		got := QuoteEnvValue(tt.value)
+		if got != tt.want {
+			t.Errorf("QuoteEnvValue(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+		// Values must read back unchanged
+		if _, value, ok := parseEnvLine("KEY=" + got); !ok || value != tt.value {
+			t.Errorf("parseEnvLine(KEY=%s) = %q, want %q", got, value, tt.value)
+		}
+	}
+}
+
+func TestGenerateSecrets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte("SECRET_KEY=changeme\nPORT=80\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := GenerateSecrets(path, map[string]int{"SECRET_KEY": 32, "CREDS_IV": 16}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	env, err := ReadEnvFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(env["SECRET_KEY"]) != 64 || env["SECRET_KEY"] == "changeme" {
+		t.Errorf("SECRET_KEY = %q, want 64 random hex chars", env["SECRET_KEY"])
+	}
+	if len(env["CREDS_IV"]) != 32 {
+		t.Errorf("CREDS_IV = %q, want 32 hex chars", env["CREDS_IV"])
+	}
+	if env["PORT"] != "80" {
+		t.Errorf("PORT = %q, want it untouched", env["PORT"])
+	}
+}
+
+func TestGenerateSecretsRefs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	example := "REDIS_PASSWORD=difyai123456\nCELERY_BROKER_URL=redis://:difyai123456@redis:6379/1\n" +
+		"CODE_EXECUTION_API_KEY=dify-sandbox\nSANDBOX_API_KEY=dify-sandbox\n"
+	if err := os.WriteFile(path, []byte(example), 0644); err != nil {
+		t.Fatal(err)
+	}
+	secrets := map[string]int{"REDIS_PASSWORD": 24, "CODE_EXECUTION_API_KEY": 24}
+	refs := map[string]string{
+		"CELERY_BROKER_URL": "redis://:{REDIS_PASSWORD}@redis:6379/1",
+		"SANDBOX_API_KEY":   "{CODE_EXECUTION_API_KEY}",
+	}
+	if err := GenerateSecrets(path, secrets, refs); err != nil {
+		t.Fatal(err)
+	}
+
+	env, err := ReadEnvFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if env["REDIS_PASSWORD"] == "difyai123456" {
+		t.Errorf("REDIS_PASSWORD kept its example value")
+	}
+	if want := "redis://:" + env["REDIS_PASSWORD"] + "@redis:6379/1"; env["CELERY_BROKER_URL"] != want {
+		t.Errorf("CELERY_BROKER_URL = %q, want %q", env["CELERY_BROKER_URL"], want)
+	}
+	if env["SANDBOX_API_KEY"] != env["CODE_EXECUTION_API_KEY"] || env["SANDBOX_API_KEY"] == "dify-sandbox" {
+		t.Errorf("SANDBOX_API_KEY = %q, want the generated CODE_EXECUTION_API_KEY %q", env["SANDBOX_API_KEY"], env["CODE_EXECUTION_API_KEY"])
+	}
+
+	if err := GenerateSecrets(path, nil, map[string]string{"URL": "{MISSING}"}); err == nil {
+		t.Error("GenerateSecrets() with a reference to an unknown secret succeeded")
+	}
+}
@@ -4,11 +4,30 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path"
 	"strings"
 
 	"github.com/getoai/getoai-cli/internal/platform"
 )
 
+// PullError is returned by installs and upgrades whose image pull failed,
+// after the registry mirror help was shown
+type PullError struct {
+	Image string
+	Err   error
+}
+
+func (e *PullError) Error() string { return "failed to pull image: " + e.Err.Error() }
+
+func (e *PullError) Unwrap() error { return e.Err }
+
+// FromDockerHub reports whether the image comes from Docker Hub, the
+// registry the daemon's registry mirrors stand in for
+func (e *PullError) FromDockerHub() bool {
+	registry, _ := splitImageRegistry(e.Image)
+	return registry == "docker.io"
+}
+
 type InstallMethod string
 
 const (
@@ -319,165 +338,111 @@ func NewDockerInstaller() *DockerInstaller {
 func (d *DockerInstaller) Name() string { return "docker" }
 
 func (d *DockerInstaller) IsAvailable() bool {
-	return d.platform.HasDocker
+	_, err := exec.LookPath(containerCLI())
+	return err == nil
 }
 
-// CheckDockerAvailable checks if Docker is available and running
-func CheckDockerAvailable() bool {
-	// Check if docker command exists
-	if _, err := exec.LookPath("docker"); err != nil {
-		fmt.Println()
-		fmt.Println("\033[33mDocker is not installed.\033[0m")
-		fmt.Println()
-		fmt.Println("Install Docker first:")
-		fmt.Println("  getoai install docker")
-		fmt.Println()
-		fmt.Println("Or install manually from: https://www.docker.com")
-		fmt.Println()
-		return false
+// CheckComposeAvailable checks if a compose implementation for the selected
+// container runtime is installed
+func CheckComposeAvailable() bool {
+	if Runtime().Compose() != nil {
+		return true
 	}
-
-	// Check if Docker daemon is running
-	out, err := exec.Command("docker", "info").CombinedOutput()
-	if err != nil {
+	fmt.Println()
+	switch Runtime().Name() {
+	case "podman":
+		fmt.Println("\033[33mPodman Compose is not installed.\033[0m")
 		fmt.Println()
-		fmt.Println("\033[33mDocker is installed but not running.\033[0m")
+		fmt.Println("Install podman-compose first:")
+		fmt.Println("  pip install podman-compose")
+	default:
+		fmt.Println("\033[33mDocker Compose is not installed.\033[0m")
 		fmt.Println()
-		// Check for common error messages
-		outStr := string(out)
-		if strings.Contains(outStr, "Cannot connect to the Docker daemon") ||
-			strings.Contains(outStr, "Is the docker daemon running") ||
-			strings.Contains(outStr, "permission denied") {
-			fmt.Println("Please start Docker Desktop or the Docker service:")
-			fmt.Println()
-			fmt.Println("  macOS/Windows: Start Docker Desktop application")
-			fmt.Println("  Linux:         sudo systemctl start docker")
-			fmt.Println()
-		} else {
-			fmt.Printf("Error: %s\n", strings.TrimSpace(outStr))
-			fmt.Println()
-		}
-		return false
-	}
-	return true
-}
-
-// CheckDockerComposeAvailable checks if docker-compose is available
-func CheckDockerComposeAvailable() bool {
-	// Check docker compose v2
-	out, _ := exec.Command("docker", "compose", "version").CombinedOutput()
-	if strings.Contains(string(out), "Docker Compose") {
-		return true
+		fmt.Println("Install Docker Compose first:")
+		fmt.Println("  getoai install docker-compose")
 	}
-	// Check docker-compose v1
-	if _, err := exec.LookPath("docker-compose"); err == nil {
-		return true
-	}
-	fmt.Println()
-	fmt.Println("\033[33mDocker Compose is not installed.\033[0m")
-	fmt.Println()
-	fmt.Println("Install Docker Compose first:")
-	fmt.Println("  getoai install docker-compose")
 	fmt.Println()
 	return false
 }
 
 func (d *DockerInstaller) Install(image string, args ...string) error {
 	// Check if Docker is running first
-	if !CheckDockerAvailable() {
-		return fmt.Errorf("docker is required but not running")
+	if !CheckContainerRuntime() {
+		return fmt.Errorf("%s is required but not running", Runtime().Name())
 	}
 
 	// Pull the image
-	allArgs := append([]string{"pull", image}, args...)
-	if err := d.RunCommand("docker", allArgs...); err != nil {
+	if err := d.PullImage(image, args...); err != nil {
 		showDockerMirrorHelp()
-		return err
+		return &PullError{Image: image, Err: err}
 	}
 	return nil
 }
 
-// InstallAndRun pulls the image and runs the container in the background
-func (d *DockerInstaller) InstallAndRun(image string, containerName string, ports []string, env map[string]string, volumes []string) error {
+// InstallAndRun pulls the image and runs the container in the background.
+// replaces, if set, names a container the new one takes over from under
+// another name: it is only stopped right before the run, and removed once
+// the new container runs or started again if it fails.
+func (d *DockerInstaller) InstallAndRun(spec ContainerSpec, replaces string) error {
 	// Check dependencies
-	if !CheckDockerAvailable() {
-		return fmt.Errorf("docker is required but not running")
+	if !CheckContainerRuntime() {
+		return fmt.Errorf("%s is required but not running", Runtime().Name())
 	}
 
 	// First pull the image
-	fmt.Printf("Pulling image %s...\n", image)
-	if err := d.RunCommand("docker", "pull", image); err != nil {
+	fmt.Printf("Pulling image %s...\n", spec.Image)
+	if err := d.PullImage(spec.Image); err != nil {
 		showDockerMirrorHelp()
-		return fmt.Errorf("failed to pull image: %w", err)
+		return &PullError{Image: spec.Image, Err: err}
 	}
 
-	// Check if container already exists
-	out, _ := d.RunCommandSilent("docker", "ps", "-a", "--filter", fmt.Sprintf("name=^%s$", containerName), "--format", "{{.Names}}")
-	if strings.TrimSpace(out) == containerName {
-		fmt.Printf("Container '%s' already exists. Removing...\n", containerName)
-		_, _ = d.RunCommandSilent("docker", "rm", "-f", containerName)
-	}
-
-	// Build run command
-	runArgs := []string{"run", "-d", "--name", containerName, "--restart", "unless-stopped"}
-
-	// Add port mappings
-	for _, port := range ports {
-		runArgs = append(runArgs, "-p", port)
+	if replaces != "" {
+		// Frees the ports it may share with the new container
+		_, _ = d.RunCommandSilent(containerCLI(), "stop", replaces)
 	}
-
-	// Add environment variables
-	for k, v := range env {
-		runArgs = append(runArgs, "-e", fmt.Sprintf("%s=%s", k, v))
-	}
-
-	// Add volume mappings
-	for _, vol := range volumes {
-		runArgs = append(runArgs, "-v", vol)
+	if err := d.RunContainer(spec); err != nil {
+		if replaces != "" {
+			_, _ = d.RunCommandSilent(containerCLI(), "rm", "-f", spec.Name)
+			if _, serr := d.RunCommandSilent(containerCLI(), "start", replaces); serr == nil {
+				fmt.Printf("Container '%s' was started again\n", replaces)
+			}
+		}
+		return err
 	}
-
-	runArgs = append(runArgs, image)
-
-	fmt.Printf("Starting container '%s'...\n", containerName)
-	if err := d.RunCommand("docker", runArgs...); err != nil {
-		return fmt.Errorf("failed to start container: %w", err)
+	if replaces != "" {
+		_ = ForceRemoveContainer(replaces)
 	}
 
 	// Show container status
 	fmt.Println()
-	fmt.Printf("\033[32m✓ Container '%s' started successfully!\033[0m\n", containerName)
+	fmt.Printf("Container '%s' started\n", spec.Name)
 
 	// Show access URL if ports are mapped
-	if len(ports) > 0 {
+	if len(spec.Ports) > 0 {
 		fmt.Println()
 		fmt.Println("Access URLs:")
-		for _, port := range ports {
-			parts := strings.Split(port, ":")
-			if len(parts) >= 1 {
-				hostPort := parts[0]
-				fmt.Printf("  http://localhost:%s\n", hostPort)
-			}
+		for _, port := range spec.Ports {
+			fmt.Printf("  http://localhost:%s\n", HostPort(port))
 		}
 	}
 
-	fmt.Println()
-	fmt.Println("Useful commands:")
-	fmt.Printf("  docker logs %s      View logs\n", containerName)
-	fmt.Printf("  docker stop %s      Stop container\n", containerName)
-	fmt.Printf("  docker start %s     Start container\n", containerName)
-	fmt.Printf("  docker rm -f %s     Remove container\n", containerName)
-
 	return nil
 }
 
-// InstallWithCompose clones the repo and starts with docker-compose
-func (d *DockerInstaller) InstallWithCompose(repoURL string, appName string) error {
+// InstallWithCompose fetches the pinned compose app from src and starts it
+// with docker-compose. An app that is already installed is restarted as is;
+// UpdateCompose moves it to another ref. Busy host ports are handed to
+// choose before the containers are started. A new .env gets a random value
+// for each key in secrets and the secretRefs built from them, see
+// GenerateSecrets. A non-empty project names the compose project, to run a
+// named instance next to the default one.
+func (d *DockerInstaller) InstallWithCompose(src ComposeSource, appName, project string, secrets map[string]int, secretRefs map[string]string, choose PortChooser) error {
 	// Check dependencies
-	if !CheckDockerAvailable() {
-		return fmt.Errorf("docker is required but not running")
+	if !CheckContainerRuntime() {
+		return fmt.Errorf("%s is required but not running", Runtime().Name())
 	}
-	if !CheckDockerComposeAvailable() {
-		return fmt.Errorf("docker-compose is required but not available")
+	if !CheckComposeAvailable() {
+		return fmt.Errorf("compose is required but not available")
 	}
 
 	// Get install directory
@@ -489,17 +454,13 @@ func (d *DockerInstaller) InstallWithCompose(repoURL string, appName string) err
 	installDir := fmt.Sprintf("%s/.getoai/tools/%s", homeDir, appName)
 
 	// Check if already installed
+	fetched := false
 	if _, err := os.Stat(installDir); err == nil {
 		fmt.Printf("Directory %s already exists.\n", installDir)
-		fmt.Println("Updating and restarting...")
-
-		// Pull latest changes
-		if err := d.RunCommand("git", "-C", installDir, "pull"); err != nil {
-			fmt.Printf("Warning: failed to pull updates: %v\n", err)
-		}
+		fmt.Println("Restarting... (use 'getoai update' to change versions)")
 	} else {
-		// Clone the repository
-		fmt.Printf("Cloning %s...\n", repoURL)
+		// Fetch only the pinned directory of the repository
+		fmt.Printf("Fetching %s (%s)...\n", src.Repo, src.RefName())
 
 		// Create parent directory
 		parentDir := fmt.Sprintf("%s/.getoai/tools", homeDir)
@@ -507,18 +468,19 @@ func (d *DockerInstaller) InstallWithCompose(repoURL string, appName string) err
 			return fmt.Errorf("failed to create directory: %w", err)
 		}
 
-		if err := d.RunCommand("git", "clone", repoURL, installDir); err != nil {
-			return fmt.Errorf("failed to clone repository: %w", err)
+		if err := src.Fetch(installDir); err != nil {
+			return fmt.Errorf("failed to fetch repository: %w", err)
 		}
+		fetched = true
 	}
 
 	// Find docker-compose file
-	composeFile := findComposeFile(installDir)
+	composeFile := FindComposeFile(installDir)
 	if composeFile == "" {
 		fmt.Println()
 		fmt.Printf("\033[33mNo docker-compose file found in %s\033[0m\n", installDir)
 		fmt.Println("Please check the repository documentation for deployment instructions.")
-		fmt.Printf("Repository: %s\n", repoURL)
+		fmt.Printf("Repository: %s\n", src.Repo)
 		return nil
 	}
 
@@ -528,46 +490,54 @@ func (d *DockerInstaller) InstallWithCompose(repoURL string, appName string) err
 	// Check for .env.example and copy to .env if .env doesn't exist
 	envExample := composeDir + "/.env.example"
 	envFile := composeDir + "/.env"
-	if _, err := os.Stat(envExample); err == nil {
-		if _, err := os.Stat(envFile); os.IsNotExist(err) {
+	if _, err := os.Stat(envFile); os.IsNotExist(err) {
+		if _, err := os.Stat(envExample); err == nil {
 			fmt.Println("Creating .env file from .env.example...")
 			if err := copyFile(envExample, envFile); err != nil {
 				fmt.Printf("Warning: failed to copy .env.example: %v\n", err)
 			}
 		}
+		// Replace the publicly known example secrets
+		if len(secrets) > 0 {
+			if err := GenerateSecrets(envFile, secrets, secretRefs); err != nil {
+				return fmt.Errorf("failed to generate secrets: %w", err)
+			}
+			fmt.Printf("Generated %d secrets in %s\n", len(secrets), envFile)
+		}
 	}
 
-	// Start with docker-compose
-	fmt.Printf("Starting %s with docker-compose...\n", appName)
-
-	// Check for docker compose v2 vs v1
-	var composeCmd string
-	var composeArgs []string
-	if _, err := exec.LookPath("docker"); err == nil {
-		// Try docker compose (v2) first
-		out, _ := exec.Command("docker", "compose", "version").CombinedOutput()
-		if strings.Contains(string(out), "Docker Compose") {
-			composeCmd = "docker"
-			composeArgs = []string{"compose", "-f", composeFile, "up", "-d"}
+	// A named instance gets its own project, which namespaces its
+	// containers, volumes and networks
+	if project != "" {
+		if err := SetEnvValue(envFile, "COMPOSE_PROJECT_NAME", project); err != nil {
+			return fmt.Errorf("failed to set the compose project name: %w", err)
 		}
-	}
-	if composeCmd == "" {
-		// Fallback to docker-compose (v1)
-		if _, err := exec.LookPath("docker-compose"); err == nil {
-			composeCmd = "docker-compose"
-			composeArgs = []string{"-f", composeFile, "up", "-d"}
+		if names := ComposeContainerNames(composeFile); len(names) > 0 {
+			if fetched {
+				os.RemoveAll(installDir)
+			}
+			return fmt.Errorf("the compose file sets fixed container names (%s), so it can't run a second instance", strings.Join(names, ", "))
 		}
 	}
 
-	if composeCmd == "" {
-		return fmt.Errorf("docker-compose is not installed. Please install it first")
+	// Check the host ports, unless the app is already up and holding them
+	ports, err := ComposePorts(composeFile)
+	if err != nil {
+		fmt.Printf("Warning: could not check ports: %v\n", err)
+	} else if out, _ := ComposeOutput(composeFile, "ps", "-q"); strings.TrimSpace(string(out)) == "" {
+		if err := resolveComposePorts(composeFile, envFile, ports, choose); err != nil {
+			return err
+		}
 	}
 
-	// Change to compose directory and run
-	cmd := exec.Command(composeCmd, composeArgs...)
-	cmd.Dir = composeDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	// Start with docker-compose
+	fmt.Printf("Starting %s with docker-compose...\n", appName)
+
+	d.pullComposeImages(composeFile)
+	cmd, err := composeCommand(composeFile, "up", "-d")
+	if err != nil {
+		return err
+	}
 	cmd.Stdin = os.Stdin
 
 	if err := cmd.Run(); err != nil {
@@ -575,26 +545,41 @@ func (d *DockerInstaller) InstallWithCompose(repoURL string, appName string) err
 		showDockerMirrorHelp()
 		return fmt.Errorf("failed to start containers: %w", err)
 	}
+	connectCompose(composeFile)
 
 	// Show success message
 	fmt.Println()
-	fmt.Printf("\033[32m✓ %s started successfully!\033[0m\n", appName)
+	fmt.Printf("%s containers started\n", appName)
 	fmt.Println()
 	fmt.Printf("Install location: %s\n", installDir)
-	fmt.Println()
-	fmt.Println("Useful commands:")
-	fmt.Printf("  cd %s && docker compose ps      View containers\n", composeDir)
-	fmt.Printf("  cd %s && docker compose logs    View logs\n", composeDir)
-	fmt.Printf("  cd %s && docker compose down    Stop services\n", composeDir)
-	fmt.Printf("  cd %s && docker compose up -d   Start services\n", composeDir)
+
+	if len(ports) > 0 {
+		fmt.Println()
+		fmt.Println("Access URLs:")
+		for _, p := range ports {
+			fmt.Printf("  http://localhost:%d  (%s)\n", p.Port, p.Service)
+		}
+	}
 
 	return nil
 }
 
-// findComposeFile looks for docker-compose file in common locations
-func findComposeFile(baseDir string) string {
+// FindComposeFile looks for docker-compose file in the pinned sub-path of
+// the app, then in common locations
+func FindComposeFile(baseDir string) string {
+	var locations []string
+	if src := ReadComposeSource(baseDir); src != nil && (src.Path != "" || src.File != "") {
+		names := []string{"docker-compose.yaml", "docker-compose.yml", "compose.yaml", "compose.yml"}
+		if src.File != "" {
+			names = []string{src.File}
+		}
+		for _, name := range names {
+			locations = append(locations, path.Join(src.Path, name))
+		}
+	}
+
 	// Common locations for docker-compose files
-	locations := []string{
+	locations = append(locations,
 		"docker/docker-compose.yaml",
 		"docker/docker-compose.yml",
 		"docker-compose.yaml",
@@ -603,7 +588,7 @@ func findComposeFile(baseDir string) string {
 		"compose.yml",
 		"deploy/docker-compose.yaml",
 		"deploy/docker-compose.yml",
-	}
+	)
 
 	for _, loc := range locations {
 		path := fmt.Sprintf("%s/%s", baseDir, loc)
@@ -627,81 +612,51 @@ func copyFile(src, dst string) error {
 func showDockerMirrorHelp() {
 	fmt.Println()
 	fmt.Println("\033[33m╭─────────────────────────────────────────────────────────────────╮\033[0m")
-	fmt.Println("\033[33m│ Docker 拉取镜像超时？请配置镜像加速器                              │\033[0m")
+	fmt.Println("\033[33m│ Docker 拉取镜像超时？请配置镜像加速器                           │\033[0m")
+	fmt.Println("\033[33m│ Docker pull timed out? Set up a registry mirror                 │\033[0m")
 	fmt.Println("\033[33m╰─────────────────────────────────────────────────────────────────╯\033[0m")
 	fmt.Println()
-	fmt.Println("编辑 Docker 配置文件:")
+	fmt.Println("让 getoai 自动配置（检查镜像可用性、备份并合并 daemon.json）:")
+	fmt.Println("Let getoai set them up (checks the mirrors, backs up and merges daemon.json):")
+	fmt.Println("  getoai docker mirrors set " + strings.Join(SuggestedMirrors, " "))
 	fmt.Println()
-	fmt.Println("  \033[36m# Linux/macOS\033[0m")
-	fmt.Println("  sudo mkdir -p /etc/docker")
-	fmt.Println("  sudo tee /etc/docker/daemon.json <<EOF")
-	fmt.Println("  {")
-	fmt.Println("    \"registry-mirrors\": [")
-	fmt.Println("      \"https://docker.1ms.run\",")
-	fmt.Println("      \"https://docker.xuanyuan.me\"")
-	fmt.Println("    ]")
-	fmt.Println("  }")
-	fmt.Println("  EOF")
-	fmt.Println("  sudo systemctl restart docker  # Linux")
-	fmt.Println("  # macOS: 重启 Docker Desktop")
+	fmt.Println("也可以换用其他镜像加速器，已有的配置会保留:")
+	fmt.Println("Other mirrors work too, the existing settings are kept:")
+	fmt.Println("  getoai docker mirrors set <url...>")
 	fmt.Println()
-	fmt.Println("或者使用 Docker Desktop 设置:")
-	fmt.Println("  Settings -> Docker Engine -> 添加 registry-mirrors")
-	fmt.Println()
-	fmt.Println("常用镜像加速器:")
+	fmt.Println("常用镜像加速器 / Common mirrors:")
 	fmt.Println("  • https://docker.1ms.run")
 	fmt.Println("  • https://docker.xuanyuan.me")
 	fmt.Println("  • https://dockerhub.icu")
 	fmt.Println("  • https://hub.rat.dev")
 	fmt.Println()
+	fmt.Println("Docker Desktop: 设置 -> Docker Engine -> 添加 registry-mirrors，然后 Apply & restart")
+	fmt.Println("Docker Desktop: Settings -> Docker Engine -> add registry-mirrors, then Apply & restart")
+	fmt.Println()
+	fmt.Println("无法修改 daemon.json？让 getoai 通过镜像拉取（失败时回退到原地址）:")
+	fmt.Println("Can't edit daemon.json? Let getoai pull through a mirror, falling back to the original registry:")
+	fmt.Println("  getoai config set docker_mirror docker.io=docker.1ms.run")
+	fmt.Println()
 	fmt.Println("配置完成后重新运行安装命令。")
+	fmt.Println("Then run the install command again.")
 	fmt.Println()
 }
 
 func (d *DockerInstaller) Uninstall(image string, args ...string) error {
-	return d.RunCommand("docker", "rmi", image)
+	return d.RunCommand(containerCLI(), "rmi", image)
 }
 
-// StopContainer stops and removes a container by name
-func (d *DockerInstaller) StopContainer(containerName string) error {
-	_, _ = d.RunCommandSilent("docker", "stop", containerName)
-	return d.RunCommand("docker", "rm", containerName)
+// RemoveContainer stops and removes a container by name
+func (d *DockerInstaller) RemoveContainer(containerName string) error {
+	_, _ = d.RunCommandSilent(containerCLI(), "stop", containerName)
+	return d.RunCommand(containerCLI(), "rm", containerName)
 }
 
-// UninstallCompose stops containers but keeps the install directory
-func (d *DockerInstaller) UninstallCompose(installDir string) error {
-	// Find docker-compose file
-	composeFile := findComposeFile(installDir)
-	if composeFile != "" {
-		composeDir := composeFile[:strings.LastIndex(composeFile, "/")]
-
-		// Check for docker compose v2 vs v1
-		var composeCmd string
-		var composeArgs []string
-		if _, err := exec.LookPath("docker"); err == nil {
-			out, _ := exec.Command("docker", "compose", "version").CombinedOutput()
-			if strings.Contains(string(out), "Docker Compose") {
-				composeCmd = "docker"
-				composeArgs = []string{"compose", "-f", composeFile, "down"}
-			}
-		}
-		if composeCmd == "" {
-			if _, err := exec.LookPath("docker-compose"); err == nil {
-				composeCmd = "docker-compose"
-				composeArgs = []string{"-f", composeFile, "down"}
-			}
-		}
-
-		if composeCmd != "" {
-			fmt.Println("Stopping containers...")
-			cmd := exec.Command(composeCmd, composeArgs...)
-			cmd.Dir = composeDir
-			cmd.Stdout = os.Stdout
-			cmd.Stderr = os.Stderr
-			if err := cmd.Run(); err != nil {
-				return fmt.Errorf("failed to stop containers: %w", err)
-			}
-		}
+// UninstallCompose stops containers but keeps the install directory. id is
+// how the app is addressed on the command line, for the purge hint.
+func (d *DockerInstaller) UninstallCompose(installDir, id string) error {
+	if err := d.DownCompose(installDir); err != nil {
+		return err
 	}
 
 	// Show manual cleanup instructions
@@ -710,13 +665,30 @@ func (d *DockerInstaller) UninstallCompose(installDir string) error {
 	fmt.Printf("Data directory: %s\n", installDir)
 	fmt.Println()
 	fmt.Println("To completely remove (including all data):")
-	fmt.Println("  macOS:  Move to Trash manually or use Finder")
-	fmt.Println("  Linux:  trash-put or move to ~/.local/share/Trash/")
+	fmt.Printf("  getoai uninstall --purge %s\n", id)
 	fmt.Println()
 
 	return nil
 }
 
+// DownCompose stops and removes the containers of a compose app, keeping
+// its directory and volumes
+func (d *DockerInstaller) DownCompose(installDir string) error {
+	composeFile := FindComposeFile(installDir)
+	if composeFile == "" {
+		return nil
+	}
+	cmd, err := composeCommand(composeFile, "down")
+	if err != nil {
+		return err
+	}
+	fmt.Println("Stopping containers...")
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to stop containers: %w", err)
+	}
+	return nil
+}
+
 // DownloadInstaller - shows download instructions for desktop apps
 type DownloadInstaller struct {
 	*BaseInstaller
@@ -765,17 +737,7 @@ func (d *DownloadInstaller) Install(url string, args ...string) error {
 }
 
 func (d *DownloadInstaller) openBrowser(url string) error {
-	var cmd *exec.Cmd
-	switch d.platform.OS {
-	case "darwin":
-		cmd = exec.Command("open", url)
-	case "linux":
-		cmd = exec.Command("xdg-open", url)
-	case "windows":
-		cmd = exec.Command("cmd", "/c", "start", url)
-	}
-
-	if cmd != nil {
+	if cmd := browserCommand(d.platform.OS, url); cmd != nil {
 		fmt.Print("Opening download page in browser... ")
 		if err := cmd.Run(); err == nil {
 			fmt.Println("done")
@@ -788,6 +750,33 @@ func (d *DownloadInstaller) openBrowser(url string) error {
 	return nil
 }
 
+// OpenURL opens url in the default browser
+func OpenURL(url string) error {
+	p := platform.Detect()
+	cmd := browserCommand(p.OS, url)
+	if cmd == nil {
+		return fmt.Errorf("don't know how to open a browser on %s", p.OS)
+	}
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to open browser: %w", err)
+	}
+	return nil
+}
+
+// browserCommand returns the command opening url in the default browser,
+// nil on unsupported systems
+func browserCommand(goos, url string) *exec.Cmd {
+	switch goos {
+	case "darwin":
+		return exec.Command("open", url)
+	case "linux":
+		return exec.Command("xdg-open", url)
+	case "windows":
+		return exec.Command("cmd", "/c", "start", url)
+	}
+	return nil
+}
+
 func (d *DownloadInstaller) downloadAndInstall(appName, downloadURL, fileType string) error {
 	// Create temp directory
 	tmpDir := os.TempDir()
@@ -966,6 +955,11 @@ func (d *DownloadInstaller) installPKG(pkgPath string) error {
 }
 
 func (d *DownloadInstaller) installDEB(debPath string) error {
+	// dpkg is only usable on Debian-based distributions
+	if d.platform.Distro != "" && !d.platform.IsDebianLike() {
+		return fmt.Errorf("DEB packages require a Debian-based distribution (detected %s), download saved to %s", d.platform.Distro, debPath)
+	}
+
 	fmt.Println("Installing DEB package...")
 	fmt.Println("This requires administrator privileges.")
 	fmt.Println()
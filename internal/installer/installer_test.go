@@ -1,6 +1,9 @@
 package installer
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestDetectFileType(t *testing.T) {
 	tests := []struct {
@@ -155,3 +158,27 @@ func TestGetFileNameFromURL(t *testing.T) {
 		})
 	}
 }
+
+func TestBrowserCommand(t *testing.T) {
+	tests := []struct {
+		goos string
+		want []string
+	}{
+		{"darwin", []string{"open", "http://localhost:3000"}},
+		{"linux", []string{"xdg-open", "http://localhost:3000"}},
+		{"windows", []string{"cmd", "/c", "start", "http://localhost:3000"}},
+		{"plan9", nil},
+	}
+	for _, tt := range tests {
+		cmd := browserCommand(tt.goos, "http://localhost:3000")
+		if tt.want == nil {
+			if cmd != nil {
+				t.Errorf("browserCommand(%s) = %v, want nil", tt.goos, cmd.Args)
+			}
+			continue
+		}
+		if cmd == nil || strings.Join(cmd.Args, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("browserCommand(%s) = %v, want %v", tt.goos, cmd, tt.want)
+		}
+	}
+}
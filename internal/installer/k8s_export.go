@@ -0,0 +1,193 @@
+package installer
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// K8sApp describes a container to deploy on Kubernetes
+type K8sApp struct {
+	Name      string
+	Spec      ContainerSpec
+	SecretEnv []string // env keys moved into a Secret instead of the Deployment
+	MemoryGB  float64  // memory request, none if 0
+	CPUCores  int      // CPU request, none if 0
+	StorageGB int      // size of each PersistentVolumeClaim
+	ReadyHTTP string   // HTTP readiness path, e.g. "/health"
+	ReadyPort int      // container port of the readiness probe, the main port if 0
+}
+
+// K8sManifests renders a Deployment, a Service for its ports, a Secret for
+// SecretEnv and a PersistentVolumeClaim for each volume, host paths
+// included. The Deployment uses the Recreate strategy since the claims are
+// ReadWriteOnce.
+func K8sManifests(app K8sApp) string {
+	name := K8sName(app.Name)
+	s := app.Spec
+	var docs []string
+
+	secret := make(map[string]bool)
+	for _, key := range app.SecretEnv {
+		if _, ok := s.Env[key]; ok {
+			secret[key] = true
+		}
+	}
+	if len(secret) > 0 {
+		var b strings.Builder
+		writeK8sHeader(&b, "v1", "Secret", name+"-env", name)
+		b.WriteString("type: Opaque\nstringData:\n")
+		for _, key := range sortedKeys(secret) {
+			fmt.Fprintf(&b, "  %s: %s\n", yamlKey(key), strconv.Quote(s.Env[key]))
+		}
+		docs = append(docs, b.String())
+	}
+
+	type mount struct{ claim, target string }
+	var mounts []mount
+	for _, v := range s.Volumes {
+		claim := K8sName(name + "-" + strings.Trim(strings.ReplaceAll(VolumeTarget(v), "/", "-"), "-"))
+		if source := VolumeSource(v); IsNamedVolume(source) {
+			claim = K8sName(source)
+		}
+		mounts = append(mounts, mount{claim: claim, target: VolumeTarget(v)})
+
+		var b strings.Builder
+		writeK8sHeader(&b, "v1", "PersistentVolumeClaim", claim, name)
+		b.WriteString("spec:\n  accessModes:\n    - ReadWriteOnce\n  resources:\n    requests:\n")
+		fmt.Fprintf(&b, "      storage: %dGi\n", max(app.StorageGB, 1))
+		docs = append(docs, b.String())
+	}
+
+	ports := k8sPorts(s.Ports)
+
+	var b strings.Builder
+	writeK8sHeader(&b, "apps/v1", "Deployment", name, name)
+	b.WriteString("spec:\n  replicas: 1\n  strategy:\n    type: Recreate\n")
+	fmt.Fprintf(&b, "  selector:\n    matchLabels:\n      app.kubernetes.io/name: %s\n", name)
+	fmt.Fprintf(&b, "  template:\n    metadata:\n      labels:\n        app.kubernetes.io/name: %s\n", name)
+	b.WriteString("    spec:\n      containers:\n")
+	fmt.Fprintf(&b, "        - name: %s\n", name)
+	fmt.Fprintf(&b, "          image: %s\n", strconv.Quote(s.Image))
+	if len(ports) > 0 {
+		b.WriteString("          ports:\n")
+		for _, p := range ports {
+			fmt.Fprintf(&b, "            - name: %s\n              containerPort: %d\n", p.name, p.port)
+			if p.protocol != "TCP" {
+				fmt.Fprintf(&b, "              protocol: %s\n", p.protocol)
+			}
+		}
+	}
+	if len(s.Env) > 0 {
+		b.WriteString("          env:\n")
+		for _, key := range sortedKeys(s.Env) {
+			fmt.Fprintf(&b, "            - name: %s\n", yamlKey(key))
+			if secret[key] {
+				fmt.Fprintf(&b, "              valueFrom:\n                secretKeyRef:\n                  name: %s-env\n                  key: %s\n", name, yamlKey(key))
+				continue
+			}
+			// $(VAR) would be expanded by Kubernetes
+			fmt.Fprintf(&b, "              value: %s\n", strconv.Quote(strings.ReplaceAll(s.Env[key], "$(", "$$(")))
+		}
+	}
+	if app.MemoryGB > 0 || app.CPUCores > 0 {
+		b.WriteString("          resources:\n            requests:\n")
+		if app.CPUCores > 0 {
+			fmt.Fprintf(&b, "              cpu: %q\n", strconv.Itoa(app.CPUCores))
+		}
+		if app.MemoryGB > 0 {
+			fmt.Fprintf(&b, "              memory: %dMi\n", int(math.Ceil(app.MemoryGB*1024)))
+		}
+	}
+	if app.ReadyHTTP != "" && len(ports) > 0 {
+		port := ports[0].port
+		if app.ReadyPort != 0 {
+			port = app.ReadyPort
+		}
+		fmt.Fprintf(&b, "          readinessProbe:\n            httpGet:\n              path: %s\n              port: %d\n", strconv.Quote(app.ReadyHTTP), port)
+		b.WriteString("            periodSeconds: 10\n            failureThreshold: 30\n")
+	}
+	if len(mounts) > 0 {
+		b.WriteString("          volumeMounts:\n")
+		for i, m := range mounts {
+			fmt.Fprintf(&b, "            - name: data-%d\n              mountPath: %s\n", i, strconv.Quote(m.target))
+		}
+		b.WriteString("      volumes:\n")
+		for i, m := range mounts {
+			fmt.Fprintf(&b, "        - name: data-%d\n          persistentVolumeClaim:\n            claimName: %s\n", i, m.claim)
+		}
+	}
+	docs = append(docs, b.String())
+
+	if len(ports) > 0 {
+		var b strings.Builder
+		writeK8sHeader(&b, "v1", "Service", name, name)
+		fmt.Fprintf(&b, "spec:\n  selector:\n    app.kubernetes.io/name: %s\n  ports:\n", name)
+		for _, p := range ports {
+			fmt.Fprintf(&b, "    - name: %s\n      port: %d\n      targetPort: %s\n", p.name, p.port, p.name)
+			if p.protocol != "TCP" {
+				fmt.Fprintf(&b, "      protocol: %s\n", p.protocol)
+			}
+		}
+		docs = append(docs, b.String())
+	}
+
+	return strings.Join(docs, "---\n")
+}
+
+func writeK8sHeader(b *strings.Builder, apiVersion, kind, name, app string) {
+	fmt.Fprintf(b, "apiVersion: %s\nkind: %s\nmetadata:\n  name: %s\n", apiVersion, kind, name)
+	fmt.Fprintf(b, "  labels:\n    app.kubernetes.io/name: %s\n    app.kubernetes.io/managed-by: getoai\n", app)
+}
+
+type k8sPort struct {
+	name     string
+	port     int
+	protocol string
+}
+
+// k8sPorts returns the container ports of the mappings, without
+// duplicates, named by protocol and port as Kubernetes requires
+func k8sPorts(mappings []string) []k8sPort {
+	var ports []k8sPort
+	seen := make(map[string]bool)
+	for _, mapping := range mappings {
+		target := ContainerPort(mapping)
+		portStr, proto, _ := strings.Cut(target, "/")
+		port, err := strconv.Atoi(portStr)
+		if err != nil || seen[target] {
+			continue
+		}
+		seen[target] = true
+		protocol := strings.ToUpper(proto)
+		if protocol == "" {
+			protocol = "TCP"
+		}
+		ports = append(ports, k8sPort{
+			name:     fmt.Sprintf("%s-%d", strings.ToLower(protocol), port),
+			port:     port,
+			protocol: protocol,
+		})
+	}
+	return ports
+}
+
+// K8sName turns a name into a valid Kubernetes resource name: lowercase
+// letters, digits and dashes, at most 63 characters
+func K8sName(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('-')
+		}
+	}
+	name := strings.Trim(b.String(), "-")
+	if len(name) > 63 {
+		name = strings.TrimRight(name[:63], "-")
+	}
+	return name
+}
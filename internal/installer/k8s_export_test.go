@@ -0,0 +1,74 @@
+package installer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestK8sName(t *testing.T) {
+	tests := map[string]string{
+		"open-webui":      "open-webui",
+		"open_webui_data": "open-webui-data",
+		"LobeChat":        "lobechat",
+		"-data-":          "data",
+	}
+	for in, want := range tests {
+		if got := K8sName(in); got != want {
+			t.Errorf("K8sName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestK8sManifests(t *testing.T) {
+	app := K8sApp{
+		Name: "web",
+		Spec: ContainerSpec{
+			Name:    "web",
+			Image:   "example/web:1.0",
+			Ports:   []string{"3001:8080", "127.0.0.1:5353:53/udp"},
+			Env:     map[string]string{"API_KEY": "s3cret", "GREETING": "$(HOME)"},
+			Volumes: []string{"web_data:/data", "./models:/models:ro"},
+		},
+		SecretEnv: []string{"API_KEY"},
+		MemoryGB:  1.5,
+		CPUCores:  2,
+		StorageGB: 8,
+		ReadyHTTP: "/health",
+	}
+	out := K8sManifests(app)
+
+	docs := strings.Split(out, "---\n")
+	var kinds []string
+	for _, doc := range docs {
+		for _, line := range strings.Split(doc, "\n") {
+			if kind, ok := strings.CutPrefix(line, "kind: "); ok {
+				kinds = append(kinds, kind)
+			}
+		}
+	}
+	if got := strings.Join(kinds, ","); got != "Secret,PersistentVolumeClaim,PersistentVolumeClaim,Deployment,Service" {
+		t.Errorf("kinds = %s", got)
+	}
+
+	for _, want := range []string{
+		"  API_KEY: \"s3cret\"\n",
+		"  name: web-data\n",
+		"  name: web-models\n",
+		"      storage: 8Gi\n",
+		"            - name: tcp-8080\n              containerPort: 8080\n",
+		"            - name: udp-53\n              containerPort: 53\n              protocol: UDP\n",
+		"                secretKeyRef:\n                  name: web-env\n                  key: API_KEY\n",
+		"              value: \"$$(HOME)\"\n",
+		"              cpu: \"2\"\n              memory: 1536Mi\n",
+		"              path: \"/health\"\n              port: 8080\n",
+		"            - name: data-1\n              mountPath: \"/models\"\n",
+		"    - name: udp-53\n      port: 53\n      targetPort: udp-53\n      protocol: UDP\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("manifests missing %q", want)
+		}
+	}
+	if strings.Count(out, "s3cret") != 1 {
+		t.Error("secret value leaked outside the Secret")
+	}
+}
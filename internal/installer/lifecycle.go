@@ -0,0 +1,82 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// composeCommand builds a compose command for the given compose file using
+// the selected container runtime's compose implementation. The command runs
+// in the compose file's directory with output attached to the terminal.
+func composeCommand(composeFile string, args ...string) (*exec.Cmd, error) {
+	prefix := Runtime().Compose()
+	if prefix == nil {
+		return nil, fmt.Errorf("compose is not installed for %s. Please install it first", Runtime().Name())
+	}
+	cmdArgs := append(append(prefix[1:len(prefix):len(prefix)], "-f", composeFile), args...)
+	cmd := exec.Command(prefix[0], cmdArgs...)
+
+	cmd.Dir = filepath.Dir(composeFile)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd, nil
+}
+
+// Compose runs a docker compose subcommand, e.g. "up -d", "stop" or
+// "logs -f", for the app installed in installDir
+func (d *DockerInstaller) Compose(installDir string, args ...string) error {
+	composeFile := FindComposeFile(installDir)
+	if composeFile == "" {
+		return fmt.Errorf("no docker-compose file found in %s", installDir)
+	}
+	cmd, err := composeCommand(composeFile, args...)
+	if err != nil {
+		return err
+	}
+	cmd.Stdin = os.Stdin
+	return cmd.Run()
+}
+
+// StartContainer starts an existing, stopped container
+func (d *DockerInstaller) StartContainer(containerName string) error {
+	return d.RunCommand(containerCLI(), "start", containerName)
+}
+
+// StopContainer stops a running container without removing it
+func (d *DockerInstaller) StopContainer(containerName string) error {
+	return d.RunCommand(containerCLI(), "stop", containerName)
+}
+
+// RestartContainer restarts a container
+func (d *DockerInstaller) RestartContainer(containerName string) error {
+	return d.RunCommand(containerCLI(), "restart", containerName)
+}
+
+// ContainerLogs prints the last tail lines of a container's logs (all of
+// them if tail is 0), and keeps streaming new lines if follow is set
+func (d *DockerInstaller) ContainerLogs(containerName string, follow bool, tail int) error {
+	args := []string{"logs"}
+	if follow {
+		args = append(args, "-f")
+	}
+	if tail > 0 {
+		args = append(args, "--tail", strconv.Itoa(tail))
+	}
+	args = append(args, containerName)
+	return d.RunCommand(containerCLI(), args...)
+}
+
+// ContainerState returns the state of a container as reported by docker
+// ("running", "exited", "restarting", ...), or an empty string if the
+// container does not exist
+func ContainerState(containerName string) string {
+	out, err := RunCommandSilent(containerCLI(), "inspect", "-f", "{{.State.Status}}", containerName)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
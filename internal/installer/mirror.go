@@ -0,0 +1,154 @@
+package installer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getoai/getoai-cli/internal/config"
+)
+
+// DefaultMirrorKey maps the registries without a mirror of their own
+const DefaultMirrorKey = "*"
+
+// ParseDockerMirror parses the docker_mirror setting: a mirror prefix used
+// for every registry ("mirror.example"), or comma-separated registry=mirror
+// pairs ("ghcr.io=ghcr.example,docker.io=hub.example,*=mirror.example").
+// An empty value clears the setting.
+func ParseDockerMirror(value string) (map[string]string, error) {
+	mirrors := make(map[string]string)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		registry, mirror, ok := strings.Cut(entry, "=")
+		if !ok {
+			registry, mirror = DefaultMirrorKey, entry
+		}
+		registry = strings.TrimSpace(registry)
+		mirror = trimMirror(mirror)
+		if registry == "" || mirror == "" || strings.ContainsAny(mirror, " \t") {
+			return nil, fmt.Errorf("invalid docker mirror %q, expected <mirror> or <registry>=<mirror>", entry)
+		}
+		if _, dup := mirrors[registry]; dup {
+			return nil, fmt.Errorf("docker mirror for %s given twice", registry)
+		}
+		mirrors[registry] = mirror
+	}
+	if len(mirrors) == 0 {
+		return nil, nil
+	}
+	return mirrors, nil
+}
+
+// FormatDockerMirror returns mirrors in the form ParseDockerMirror reads
+func FormatDockerMirror(mirrors map[string]string) string {
+	if len(mirrors) == 1 && mirrors[DefaultMirrorKey] != "" {
+		return mirrors[DefaultMirrorKey]
+	}
+	pairs := make([]string, 0, len(mirrors))
+	for _, registry := range sortedKeys(mirrors) {
+		pairs = append(pairs, registry+"="+mirrors[registry])
+	}
+	return strings.Join(pairs, ",")
+}
+
+// trimMirror drops the scheme and trailing slash of a mirror, image
+// references have neither
+func trimMirror(mirror string) string {
+	mirror = strings.TrimSpace(mirror)
+	mirror = strings.TrimPrefix(mirror, "https://")
+	mirror = strings.TrimPrefix(mirror, "http://")
+	return strings.TrimRight(mirror, "/")
+}
+
+// splitImageRegistry splits an image reference into its registry and the
+// repository path, the way docker resolves them: "nginx" is
+// docker.io/library/nginx
+func splitImageRegistry(image string) (registry, path string) {
+	first, rest, ok := strings.Cut(image, "/")
+	if ok && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		registry, path = first, rest
+	} else {
+		registry, path = "docker.io", image
+		if !ok {
+			path = "library/" + image
+		}
+	}
+	if registry == "index.docker.io" || registry == "registry-1.docker.io" {
+		registry = "docker.io"
+	}
+	return registry, path
+}
+
+// MirrorImage returns the reference image is pulled from through mirrors,
+// or "" if its registry has no mirror. A mirror set for the registry
+// replaces it; the default mirror is put in front of the whole reference
+// (ghcr.io/x becomes mirror.example/ghcr.io/x).
+func MirrorImage(image string, mirrors map[string]string) string {
+	if len(mirrors) == 0 || image == "" {
+		return ""
+	}
+	for _, mirror := range mirrors {
+		if strings.HasPrefix(image, mirror+"/") {
+			// Already pulled from a mirror
+			return ""
+		}
+	}
+	registry, path := splitImageRegistry(image)
+	if mirror, ok := mirrors[registry]; ok {
+		return mirror + "/" + path
+	}
+	if mirror, ok := mirrors[DefaultMirrorKey]; ok {
+		return mirror + "/" + registry + "/" + path
+	}
+	return ""
+}
+
+// dockerMirrors returns the configured docker_mirror mappings
+func dockerMirrors() map[string]string {
+	if cfg := config.Get(); cfg != nil {
+		return cfg.DockerMirror
+	}
+	return nil
+}
+
+// PullImage pulls image, through the configured docker mirror if its
+// registry has one. The mirrored image is tagged with the original
+// reference, so containers, receipts and updates keep using it. When the
+// mirror fails, the image is pulled from its own registry.
+func (d *DockerInstaller) PullImage(image string, args ...string) error {
+	if mirrored := MirrorImage(image, dockerMirrors()); mirrored != "" {
+		fmt.Printf("Pulling %s from mirror %s...\n", image, mirrored)
+		err := d.RunCommand(containerCLI(), append([]string{"pull", mirrored}, args...)...)
+		if err == nil {
+			if out, terr := d.RunCommandSilent(containerCLI(), "tag", mirrored, image); terr != nil {
+				err = fmt.Errorf("failed to tag %s: %s", mirrored, lastLine(strings.TrimSpace(out)))
+			} else {
+				// Only drops the mirror's tag, the image stays under the original one
+				_, _ = d.RunCommandSilent(containerCLI(), "rmi", mirrored)
+				return nil
+			}
+		}
+		fmt.Printf("\033[33m!\033[0m Mirror failed (%v), pulling %s directly\n", err, image)
+	}
+	return d.RunCommand(containerCLI(), append([]string{"pull", image}, args...)...)
+}
+
+// pullComposeImages pulls the missing images of a compose app through the
+// configured docker mirror before compose starts it, which would pull them
+// from their own registries. Failures are left for compose to report.
+func (d *DockerInstaller) pullComposeImages(composeFile string) {
+	if len(dockerMirrors()) == 0 || composeFile == "" {
+		return
+	}
+	images := ComposeImages(composeFile)
+	sort.Strings(images)
+	for _, image := range images {
+		if _, err := RunCommandSilent(containerCLI(), "image", "inspect", image); err == nil {
+			continue
+		}
+		_ = d.PullImage(image)
+	}
+}
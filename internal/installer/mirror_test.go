@@ -0,0 +1,78 @@
+package installer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestMirrorImage(t *testing.T) {
+	mirrors := map[string]string{
+		"docker.io":      "hub.example",
+		"ghcr.io":        "ghcr.example",
+		DefaultMirrorKey: "mirror.example",
+	}
+	tests := []struct {
+		image   string
+		mirrors map[string]string
+		want    string
+	}{
+		{"ollama/ollama:latest", mirrors, "hub.example/ollama/ollama:latest"},
+		{"nginx", mirrors, "hub.example/library/nginx"},
+		{"docker.io/library/redis:7", mirrors, "hub.example/library/redis:7"},
+		{"ghcr.io/open-webui/open-webui:main", mirrors, "ghcr.example/open-webui/open-webui:main"},
+		{"quay.io/minio/minio", mirrors, "mirror.example/quay.io/minio/minio"},
+		{"localhost:5000/app", mirrors, "mirror.example/localhost:5000/app"},
+		{"hub.example/library/nginx", mirrors, ""},
+		{"quay.io/minio/minio", map[string]string{"ghcr.io": "ghcr.example"}, ""},
+		{"ghcr.io/x/y", map[string]string{DefaultMirrorKey: "mirror.example"}, "mirror.example/ghcr.io/x/y"},
+		{"nginx", nil, ""},
+	}
+	for _, tt := range tests {
+		if got := MirrorImage(tt.image, tt.mirrors); got != tt.want {
+			t.Errorf("MirrorImage(%q) = %q, want %q", tt.image, got, tt.want)
+		}
+	}
+}
+
+func TestParseDockerMirror(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"https://mirror.example/", "mirror.example", false},
+		{"ghcr.io=ghcr.example, docker.io=https://hub.example", "docker.io=hub.example,ghcr.io=ghcr.example", false},
+		{"*=mirror.example,ghcr.io=ghcr.example", "*=mirror.example,ghcr.io=ghcr.example", false},
+		{"", "", false},
+		{"ghcr.io=", "", true},
+		{"=mirror.example", "", true},
+		{"a.example,b.example", "", true},
+	}
+	for _, tt := range tests {
+		mirrors, err := ParseDockerMirror(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseDockerMirror(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got := FormatDockerMirror(mirrors); got != tt.want {
+			t.Errorf("ParseDockerMirror(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestPullErrorFromDockerHub(t *testing.T) {
+	for image, want := range map[string]bool{
+		"lobehub/lobe-chat":                  true,
+		"nginx":                              true,
+		"docker.io/library/redis":            true,
+		"ghcr.io/open-webui/open-webui:main": false,
+		"localhost:5000/app":                 false,
+	} {
+		err := fmt.Errorf("install: %w", &PullError{Image: image, Err: errors.New("timeout")})
+		var pullErr *PullError
+		if !errors.As(err, &pullErr) || pullErr.FromDockerHub() != want {
+			t.Errorf("PullError{%q}.FromDockerHub() = %v, want %v", image, !want, want)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package installer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/getoai/getoai-cli/internal/config"
+)
+
+// NativeService runs a tool's server process (e.g. `ollama serve`) in the
+// background. The process is tracked with a pid file and its output is
+// appended to a log file, both under ~/.getoai. Once a systemd unit is
+// installed for it (getoai service enable), systemd runs it instead.
+type NativeService struct {
+	Name    string            // tool name, used for the pid and log files
+	Command []string          // command line to run
+	Env     map[string]string // extra environment variables
+	Port    int               // port the server listens on, 0 if unknown
+}
+
+// PidFile returns the path of the file holding the server's process ID
+func (s *NativeService) PidFile() string {
+	return config.DataDir("run", s.Name+".pid")
+}
+
+// LogFile returns the path of the file capturing the server's output
+func (s *NativeService) LogFile() string {
+	return config.DataDir("logs", s.Name+".log")
+}
+
+// PID returns the process ID of the server started by getoai, or 0 if it
+// is not running
+func (s *NativeService) PID() int {
+	if s.Managed() {
+		return UnitPID(s.Name)
+	}
+	data, err := os.ReadFile(s.PidFile())
+	if err != nil {
+		return 0
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 || !processAlive(pid) {
+		return 0
+	}
+	return pid
+}
+
+// Managed reports whether the server runs as a systemd unit
+func (s *NativeService) Managed() bool {
+	return UnitInstalled(s.Name)
+}
+
+// Running reports whether the server is up, either started by getoai or
+// by something else listening on its port (e.g. the Ollama desktop app)
+func (s *NativeService) Running() bool {
+	return s.PID() != 0 || s.portOpen()
+}
+
+func (s *NativeService) portOpen() bool {
+	return s.Port != 0 && accepts("127.0.0.1", s.Port)
+}
+
+// accepts reports whether something accepts connections on host:port
+func accepts(host string, port int) bool {
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, strconv.Itoa(port)), 500*time.Millisecond)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// Start launches the server in the background, detached from the terminal
+func (s *NativeService) Start() error {
+	if len(s.Command) == 0 {
+		return fmt.Errorf("no service command defined for %s", s.Name)
+	}
+	if s.Running() {
+		return fmt.Errorf("%s is already running", s.Name)
+	}
+	if s.Managed() {
+		return StartUnit(s.Name)
+	}
+	if _, err := exec.LookPath(s.Command[0]); err != nil {
+		return fmt.Errorf("%s not found in PATH", s.Command[0])
+	}
+
+	for _, path := range []string{s.PidFile(), s.LogFile()} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+	}
+
+	logFile, err := os.OpenFile(s.LogFile(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer logFile.Close()
+
+	cmd := exec.Command(s.Command[0], s.Command[1:]...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.Env = os.Environ()
+	for k, v := range s.Env {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", k, v))
+	}
+	detach(cmd)
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start %s: %w", s.Name, err)
+	}
+	pid := cmd.Process.Pid
+	if err := os.WriteFile(s.PidFile(), []byte(strconv.Itoa(pid)), 0644); err != nil {
+		return fmt.Errorf("failed to write pid file: %w", err)
+	}
+	// The server outlives this process; reap it if it exits while getoai
+	// is still running, so it doesn't linger as a zombie that looks alive
+	exited := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(exited)
+	}()
+
+	// Catch servers that exit right away, e.g. on a bad flag
+	select {
+	case <-exited:
+		os.Remove(s.PidFile())
+		return fmt.Errorf("%s exited right after starting, see %s", s.Name, s.LogFile())
+	case <-time.After(500 * time.Millisecond):
+	}
+	return nil
+}
+
+// Stop terminates the server started by getoai, waiting up to ten seconds
+// for it to shut down gracefully before killing it
+func (s *NativeService) Stop() error {
+	if s.Managed() {
+		if !s.Running() {
+			return fmt.Errorf("%s is not running", s.Name)
+		}
+		return StopUnit(s.Name)
+	}
+	pid := s.PID()
+	if pid == 0 {
+		if s.portOpen() {
+			return fmt.Errorf("%s was not started by getoai, stop it where it was started", s.Name)
+		}
+		return fmt.Errorf("%s is not running", s.Name)
+	}
+
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	if err := terminate(proc); err != nil {
+		return fmt.Errorf("failed to stop %s: %w", s.Name, err)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for processAlive(pid) && time.Now().Before(deadline) {
+		time.Sleep(200 * time.Millisecond)
+	}
+	if processAlive(pid) {
+		_ = proc.Kill()
+	}
+	os.Remove(s.PidFile())
+	return nil
+}
+
+// Logs prints the last tail lines of the server's log (all of them if tail
+// is 0), and keeps printing new output if follow is set
+func (s *NativeService) Logs(follow bool, tail int) error {
+	f, err := os.Open(s.LogFile())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no logs for %s yet", s.Name)
+		}
+		return err
+	}
+	defer f.Close()
+
+	lines, err := lastLines(f, tail)
+	if err != nil {
+		return err
+	}
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+	if !follow {
+		return nil
+	}
+
+	// Poll for appended output until interrupted
+	for {
+		if _, err := io.Copy(os.Stdout, f); err != nil {
+			return err
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+// lastLines reads r to the end and returns its last n lines, all of them if
+// n is 0
+func lastLines(r io.Reader, n int) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+		if n > 0 && len(lines) > n {
+			lines = lines[1:]
+		}
+	}
+	return lines, scanner.Err()
+}
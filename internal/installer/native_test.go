@@ -0,0 +1,55 @@
+package installer
+
+import (
+	"net"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestLastLines(t *testing.T) {
+	log := "one\ntwo\nthree\nfour\n"
+
+	tests := []struct {
+		name string
+		n    int
+		want []string
+	}{
+		{name: "Tail", n: 2, want: []string{"three", "four"}},
+		{name: "More than available", n: 10, want: []string{"one", "two", "three", "four"}},
+		{name: "All", n: 0, want: []string{"one", "two", "three", "four"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := lastLines(strings.NewReader(log), tt.n)
+			if err != nil {
+				t.Fatalf("lastLines() error = %v", err)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("lastLines(%d) = %v, want %v", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoopbackOnly(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("needs the whole 127.0.0.0/8 on the loopback")
+	}
+	listen := func(addr string) int {
+		l, err := net.Listen("tcp", addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { l.Close() })
+		return l.Addr().(*net.TCPAddr).Port
+	}
+
+	if port := listen("127.0.0.1:0"); !LoopbackOnly(port, "127.0.0.2") {
+		t.Error("server on 127.0.0.1: LoopbackOnly() = false")
+	}
+	if port := listen("0.0.0.0:0"); LoopbackOnly(port, "127.0.0.2") {
+		t.Error("server on all interfaces: LoopbackOnly() = true")
+	}
+}
@@ -0,0 +1,103 @@
+package installer
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// NetworkName is the user-defined network every getoai-managed container
+// joins, so containers reach each other by container name
+const NetworkName = "getoai"
+
+// HostGateway makes host.docker.internal resolve to the host inside a
+// container, which Docker on Linux doesn't do by default. Containers use it
+// to reach servers running natively on the host, e.g. `ollama serve`.
+const HostGateway = "host.docker.internal:host-gateway"
+
+// BridgeGateway returns the host's address on the default bridge network,
+// which host-gateway resolves to, or "" if it can't be told
+func BridgeGateway() string {
+	out, err := RunCommandSilent(containerCLI(), "network", "inspect", "bridge", "-f", "{{range .IPAM.Config}}{{.Gateway}} {{end}}")
+	if err != nil {
+		return ""
+	}
+	for _, field := range strings.Fields(out) {
+		if ip := net.ParseIP(field); ip != nil && ip.To4() != nil {
+			return field
+		}
+	}
+	return ""
+}
+
+// LoopbackOnly reports whether a server on the host accepts connections on
+// port at 127.0.0.1 but not at addr, e.g. the bridge gateway containers
+// reach the host through
+func LoopbackOnly(port int, addr string) bool {
+	return accepts("127.0.0.1", port) && !accepts(addr, port)
+}
+
+// EnsureNetwork creates the network if it doesn't exist yet
+func EnsureNetwork(name string) error {
+	if _, err := RunCommandSilent(containerCLI(), "network", "inspect", name); err == nil {
+		return nil
+	}
+	if out, err := RunCommandSilent(containerCLI(), "network", "create", name); err != nil {
+		// Lost a race with another getoai creating it
+		if _, ierr := RunCommandSilent(containerCLI(), "network", "inspect", name); ierr == nil {
+			return nil
+		}
+		return fmt.Errorf("failed to create network %s: %s", name, lastLine(strings.TrimSpace(out)))
+	}
+	return nil
+}
+
+// ConnectNetwork attaches a container to the network, doing nothing if it
+// is already attached
+func ConnectNetwork(name, containerName string) error {
+	out, err := RunCommandSilent(containerCLI(), "inspect", "-f", "{{range $k, $v := .NetworkSettings.Networks}}{{$k}} {{end}}", containerName)
+	if err != nil {
+		return fmt.Errorf("container %s not found", containerName)
+	}
+	for _, network := range strings.Fields(out) {
+		if network == name {
+			return nil
+		}
+	}
+	if out, err := RunCommandSilent(containerCLI(), "network", "connect", name, containerName); err != nil {
+		return fmt.Errorf("failed to connect %s to network %s: %s", containerName, name, lastLine(strings.TrimSpace(out)))
+	}
+	return nil
+}
+
+// ComposeUp starts the compose app installed in installDir and attaches its
+// containers to the getoai network. Compose recreates containers whose
+// settings changed, which drops them from networks it doesn't manage, so
+// they are attached again after every start.
+func (d *DockerInstaller) ComposeUp(installDir string) error {
+	d.pullComposeImages(FindComposeFile(installDir))
+	if err := d.Compose(installDir, "up", "-d"); err != nil {
+		return err
+	}
+	connectCompose(FindComposeFile(installDir))
+	return nil
+}
+
+// connectCompose attaches the containers of a compose app to the getoai
+// network. Failures are only reported: the app works without it, it just
+// can't reach other getoai containers by name.
+func connectCompose(composeFile string) {
+	out, err := ComposeOutput(composeFile, "ps", "-q")
+	if err != nil {
+		return
+	}
+	if err := EnsureNetwork(NetworkName); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+		return
+	}
+	for _, id := range strings.Fields(string(out)) {
+		if err := ConnectNetwork(NetworkName, id); err != nil {
+			fmt.Printf("Warning: %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package installer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PortChooser is asked what to do when a host port is already in use. It
+// gets the busy port and the next free one (0 if none was found), and
+// returns the port to use instead, or false to abort the install.
+type PortChooser func(busy, free int) (int, bool)
+
+// PortAvailable reports whether a TCP port can be bound on all interfaces,
+// the way docker publishes ports
+func PortAvailable(port int) bool {
+	ln, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return false
+	}
+	ln.Close()
+	return true
+}
+
+// NextFreePort returns the first available port after port, skipping the
+// reserved ones, or 0 if none is free in the next 100 ports
+func NextFreePort(port int, reserved map[int]bool) int {
+	for p := port + 1; p <= port+100 && p <= 65535; p++ {
+		if !reserved[p] && PortAvailable(p) {
+			return p
+		}
+	}
+	return 0
+}
+
+// ResolvePorts checks every host port of the container before it is run.
+// Ports held by the container that is about to be replaced are fine; busy
+// ones are remapped to the port picked by choose.
+func ResolvePorts(spec *ContainerSpec, choose PortChooser) error {
+	return ResolvePortsAvoiding(spec, "", nil, choose)
+}
+
+// ResolvePortsAvoiding is ResolvePorts, also treating the taken ports as
+// busy: ports of other installs that may be started later. Ports held by
+// the container replaces, which the new one takes over from under another
+// name, are fine too.
+func ResolvePortsAvoiding(spec *ContainerSpec, replaces string, taken []int, choose PortChooser) error {
+	own := make(map[int]bool)
+	for _, name := range []string{spec.Name, replaces} {
+		if name == "" {
+			continue
+		}
+		for _, p := range ContainerHostPorts(name) {
+			own[p] = true
+		}
+	}
+
+	reserved := make(map[int]bool)
+	for _, p := range taken {
+		reserved[p] = true
+	}
+	for i, mapping := range spec.Ports {
+		host, err := strconv.Atoi(HostPort(mapping))
+		if err != nil {
+			// Port ranges and random host ports are left to docker
+			continue
+		}
+		if !reserved[host] && (own[host] || PortAvailable(host)) {
+			reserved[host] = true
+			continue
+		}
+
+		port, ok := choosePort(host, reserved, choose)
+		if !ok {
+			return fmt.Errorf("port %d is already in use, choose another one with --port", host)
+		}
+		spec.Ports[i] = replaceHostPort(mapping, port)
+		reserved[port] = true
+	}
+	return nil
+}
+
+func choosePort(busy int, reserved map[int]bool, choose PortChooser) (int, bool) {
+	free := NextFreePort(busy, reserved)
+	if choose == nil {
+		return 0, false
+	}
+	port, ok := choose(busy, free)
+	if !ok || port <= 0 {
+		return 0, false
+	}
+	return port, true
+}
+
+// replaceHostPort swaps the host side of a port mapping, keeping any bind
+// address and the container port
+func replaceHostPort(mapping string, port int) string {
+	parts := strings.Split(mapping, ":")
+	if len(parts) == 1 {
+		return fmt.Sprintf("%d:%s", port, mapping)
+	}
+	parts[len(parts)-2] = strconv.Itoa(port)
+	return strings.Join(parts, ":")
+}
+
+// ContainerHostPorts returns the host ports published by an existing
+// container, nil if it doesn't exist
+func ContainerHostPorts(containerName string) []int {
+	out, err := RunCommandSilent(containerCLI(), "port", containerName)
+	if err != nil {
+		return nil
+	}
+	return parseDockerPort(out)
+}
+
+// parseDockerPort parses `docker port` output such as
+// "8080/tcp -> 0.0.0.0:3000"
+func parseDockerPort(out string) []int {
+	var ports []int
+	for _, line := range strings.Split(out, "\n") {
+		_, binding, ok := strings.Cut(line, "->")
+		if !ok {
+			continue
+		}
+		binding = strings.TrimSpace(binding)
+		if port, err := strconv.Atoi(binding[strings.LastIndex(binding, ":")+1:]); err == nil {
+			ports = append(ports, port)
+		}
+	}
+	return ports
+}
+
+// ComposePort is a host port published by a compose service
+type ComposePort struct {
+	Service string
+	Port    int
+}
+
+// ComposePorts returns the host ports published by a compose app, as
+// resolved by `docker compose config`
+func ComposePorts(composeFile string) ([]ComposePort, error) {
+	out, err := ComposeOutput(composeFile, "config", "--format", "json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read compose config: %w", err)
+	}
+	return parseComposeConfigPorts(out)
+}
+
+// ComposeOutput runs a compose subcommand and returns its output
+func ComposeOutput(composeFile string, args ...string) ([]byte, error) {
+	cmd, err := composeCommand(composeFile, args...)
+	if err != nil {
+		return nil, err
+	}
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = nil
+	err = cmd.Run()
+	return stdout.Bytes(), err
+}
+
+func parseComposeConfigPorts(data []byte) ([]ComposePort, error) {
+	var config struct {
+		Services map[string]struct {
+			Ports []struct {
+				Published interface{} `json:"published"`
+			} `json:"ports"`
+		} `json:"services"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid compose config: %w", err)
+	}
+
+	var ports []ComposePort
+	for name, svc := range config.Services {
+		for _, p := range svc.Ports {
+			// Older compose versions emit numbers, newer ones strings
+			port, err := strconv.Atoi(fmt.Sprint(p.Published))
+			if err != nil || port == 0 {
+				continue
+			}
+			ports = append(ports, ComposePort{Service: name, Port: port})
+		}
+	}
+	sort.Slice(ports, func(i, j int) bool {
+		return ports[i].Port < ports[j].Port
+	})
+	return ports, nil
+}
+
+// hostPortVariable matches a host port taken from a variable in a compose
+// port mapping, e.g. "${EXPOSE_NGINX_PORT:-80}:80"
+var hostPortVariable = regexp.MustCompile(`\$\{(\w+)(?::?-(\d+))?\}\s*:`)
+
+// portVariable finds the variable that sets a published host port in the
+// raw compose file, using the value from .env or the variable's default
+func portVariable(composeText string, env map[string]string, port int) string {
+	for _, m := range hostPortVariable.FindAllStringSubmatch(composeText, -1) {
+		value, ok := env[m[1]]
+		if !ok {
+			value, ok = os.LookupEnv(m[1])
+		}
+		if !ok {
+			value = m[2]
+		}
+		if value == strconv.Itoa(port) {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// resolveComposePorts checks the host ports of a compose app before it is
+// started. A busy port that is set through a variable is moved by writing
+// the new port to .env and updating ports; other conflicts abort the install.
+func resolveComposePorts(composeFile, envFile string, ports []ComposePort, choose PortChooser) error {
+	composeText, err := os.ReadFile(composeFile)
+	if err != nil {
+		return err
+	}
+	env, _ := ReadEnvFile(envFile)
+
+	reserved := make(map[int]bool)
+	for i, p := range ports {
+		if !reserved[p.Port] && PortAvailable(p.Port) {
+			reserved[p.Port] = true
+			continue
+		}
+
+		variable := portVariable(string(composeText), env, p.Port)
+		if variable == "" {
+			return fmt.Errorf("port %d (service %s) is already in use; free it or change the port in %s", p.Port, p.Service, composeFile)
+		}
+		port, ok := choosePort(p.Port, reserved, choose)
+		if !ok {
+			return fmt.Errorf("port %d (service %s) is already in use; set %s in %s to use another port", p.Port, p.Service, variable, envFile)
+		}
+		if err := SetEnvValue(envFile, variable, strconv.Itoa(port)); err != nil {
+			return fmt.Errorf("failed to update %s: %w", envFile, err)
+		}
+		env[variable] = strconv.Itoa(port)
+		ports[i].Port = port
+		reserved[port] = true
+	}
+	return nil
+}
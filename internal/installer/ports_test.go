@@ -0,0 +1,124 @@
+package installer
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestReplaceHostPort(t *testing.T) {
+	tests := []struct {
+		mapping string
+		port    int
+		want    string
+	}{
+		{mapping: "3000:8080", port: 3001, want: "3001:8080"},
+		{mapping: "127.0.0.1:3000:8080", port: 3001, want: "127.0.0.1:3001:8080"},
+		{mapping: "8080", port: 3001, want: "3001:8080"},
+	}
+
+	for _, tt := range tests {
+		if got := replaceHostPort(tt.mapping, tt.port); got != tt.want {
+			t.Errorf("replaceHostPort(%q, %d) = %q, want %q", tt.mapping, tt.port, got, tt.want)
+		}
+	}
+}
+
+func TestParseDockerPort(t *testing.T) {
+	out := "8080/tcp -> 0.0.0.0:3000\n8080/tcp -> [::]:3000\n9000/udp -> 127.0.0.1:9001\n"
+	got := parseDockerPort(out)
+	if len(got) != 3 || got[0] != 3000 || got[1] != 3000 || got[2] != 9001 {
+		t.Errorf("parseDockerPort() = %v, want [3000 3000 9001]", got)
+	}
+}
+
+func TestParseComposeConfigPorts(t *testing.T) {
+	data := `{"services": {
+		"nginx": {"ports": [{"target": 80, "published": "8081"}]},
+		"api": {"ports": [{"target": 5001, "published": 5001}]},
+		"db": {}
+	}}`
+	got, err := parseComposeConfigPorts([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0] != (ComposePort{Service: "api", Port: 5001}) || got[1] != (ComposePort{Service: "nginx", Port: 8081}) {
+		t.Errorf("parseComposeConfigPorts() = %v", got)
+	}
+}
+
+func TestPortVariable(t *testing.T) {
+	compose := `services:
+  nginx:
+    ports:
+      - "${EXPOSE_NGINX_PORT:-80}:${NGINX_PORT:-80}"
+      - "${EXPOSE_NGINX_SSL_PORT:-443}:443"
+  api:
+    ports:
+      - "5001:5001"
+`
+	tests := []struct {
+		name string
+		env  map[string]string
+		port int
+		want string
+	}{
+		{name: "Default value", port: 80, want: "EXPOSE_NGINX_PORT"},
+		{name: "Value from .env", env: map[string]string{"EXPOSE_NGINX_SSL_PORT": "8443"}, port: 8443, want: "EXPOSE_NGINX_SSL_PORT"},
+		{name: "Fixed port", port: 5001, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := portVariable(compose, tt.env, tt.port); got != tt.want {
+				t.Errorf("portVariable(%d) = %q, want %q", tt.port, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolvePortsRemapsBusyPort(t *testing.T) {
+	ln, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Skip("cannot bind a local port")
+	}
+	defer ln.Close()
+	busy := ln.Addr().(*net.TCPAddr).Port
+
+	spec := &ContainerSpec{Name: "getoai-test-nonexistent", Ports: []string{strconv.Itoa(busy) + ":8080"}}
+
+	if err := ResolvePorts(spec, nil); err == nil {
+		t.Error("ResolvePorts() without a chooser should fail on a busy port")
+	}
+
+	var offered int
+	err = ResolvePorts(spec, func(b, free int) (int, bool) {
+		offered = free
+		return free, true
+	})
+	if err != nil {
+		t.Fatalf("ResolvePorts() error = %v", err)
+	}
+	if offered <= busy || !strings.HasSuffix(spec.Ports[0], ":8080") || HostPort(spec.Ports[0]) != strconv.Itoa(offered) {
+		t.Errorf("ResolvePorts() mapped %d to %q, offered %d", busy, spec.Ports[0], offered)
+	}
+}
+
+func TestResolvePortsAvoidingTakenPort(t *testing.T) {
+	port := NextFreePort(41000, nil)
+	if port == 0 {
+		t.Skip("no free local port")
+	}
+	spec := &ContainerSpec{Name: "getoai-test-nonexistent", Ports: []string{strconv.Itoa(port) + ":8080"}}
+
+	err := ResolvePortsAvoiding(spec, "", []int{port}, func(b, free int) (int, bool) {
+		return free, true
+	})
+	if err != nil {
+		t.Fatalf("ResolvePortsAvoiding() error = %v", err)
+	}
+	if HostPort(spec.Ports[0]) == strconv.Itoa(port) {
+		t.Errorf("ResolvePortsAvoiding() kept taken port %d", port)
+	}
+}
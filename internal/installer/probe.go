@@ -0,0 +1,166 @@
+package installer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// probeTimeout bounds a single readiness check
+const probeTimeout = 3 * time.Second
+
+// Probe checks whether a started service is ready to serve requests. It
+// runs Command if set, otherwise requests HTTP on Port if set, otherwise
+// connects to Port over TCP.
+type Probe struct {
+	HTTP    string   // HTTP path, e.g. "/health"
+	Port    int      // host port
+	Command []string // command that exits 0 once the service is ready
+}
+
+// String describes the probe for messages
+func (p Probe) String() string {
+	switch {
+	case len(p.Command) > 0:
+		return strings.Join(p.Command, " ")
+	case p.HTTP != "":
+		return fmt.Sprintf("http://localhost:%d%s", p.Port, p.HTTP)
+	default:
+		return fmt.Sprintf("tcp port %d", p.Port)
+	}
+}
+
+// Check runs the probe once. Any HTTP response below 500 counts as ready:
+// a 401 or 404 still means the server is up and answering.
+func (p Probe) Check() error {
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+
+	switch {
+	case len(p.Command) > 0:
+		out, err := exec.CommandContext(ctx, p.Command[0], p.Command[1:]...).CombinedOutput()
+		if err != nil {
+			if msg := strings.TrimSpace(string(out)); msg != "" {
+				return fmt.Errorf("%s: %s", p.Command[0], lastLine(msg))
+			}
+			return fmt.Errorf("%s: %w", p.Command[0], err)
+		}
+		return nil
+
+	case p.HTTP != "":
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.String(), nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 500 {
+			return fmt.Errorf("%s returned %s", p.HTTP, resp.Status)
+		}
+		return nil
+
+	default:
+		conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", net.JoinHostPort("localhost", strconv.Itoa(p.Port)))
+		if err != nil {
+			return err
+		}
+		conn.Close()
+		return nil
+	}
+}
+
+// WaitReady polls the probe until it passes or timeout expires. alive, if
+// set, is asked between attempts and stops the wait early with its error,
+// e.g. when the container has exited.
+func WaitReady(p Probe, timeout time.Duration, alive func() error) error {
+	deadline := time.Now().Add(timeout)
+	var err error
+	for {
+		if err = p.Check(); err == nil {
+			return nil
+		}
+		if alive != nil {
+			if aerr := alive(); aerr != nil {
+				return aerr
+			}
+		}
+		if time.Now().Add(time.Second).After(deadline) {
+			return fmt.Errorf("not ready after %s: %w", timeout, err)
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// ContainerAlive returns an error once a container has stopped or started
+// restarting, for use with WaitReady
+func ContainerAlive(containerName string) func() error {
+	return func() error {
+		out, err := RunCommandSilent(containerCLI(), "inspect", "-f", "{{.State.Status}} {{.RestartCount}}", containerName)
+		if err != nil {
+			return fmt.Errorf("container %s not found", containerName)
+		}
+		fields := strings.Fields(out)
+		switch {
+		case len(fields) == 0:
+			return nil
+		case fields[0] == "exited" || fields[0] == "dead":
+			return fmt.Errorf("container %s", fields[0])
+		case len(fields) > 1 && fields[1] != "0":
+			return fmt.Errorf("container is restarting")
+		}
+		return nil
+	}
+}
+
+// ComposeAlive returns an error once a container of the compose app has
+// failed or started restarting, for use with WaitReady. Containers that
+// exited cleanly, such as init jobs, are fine.
+func ComposeAlive(composeFile string) func() error {
+	return func() error {
+		out, err := ComposeOutput(composeFile, "ps", "-a", "-q")
+		ids := strings.Fields(string(out))
+		if err != nil || len(ids) == 0 {
+			return nil
+		}
+		args := append([]string{"inspect", "-f", "{{.Name}} {{.State.Status}} {{.State.ExitCode}} {{.RestartCount}}"}, ids...)
+		states, err := RunCommandSilent(containerCLI(), args...)
+		if err != nil {
+			return nil
+		}
+		for _, line := range strings.Split(states, "\n") {
+			if err := composeContainerFailed(line); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// composeContainerFailed checks a "<name> <status> <exit code> <restarts>"
+// line of a compose app's container
+func composeContainerFailed(line string) error {
+	fields := strings.Fields(line)
+	if len(fields) < 4 {
+		return nil
+	}
+	name := strings.TrimPrefix(fields[0], "/")
+	switch {
+	case fields[1] == "dead" || (fields[1] == "exited" && fields[2] != "0"):
+		return fmt.Errorf("container %s exited with code %s", name, fields[2])
+	case fields[3] != "0":
+		return fmt.Errorf("container %s is restarting", name)
+	}
+	return nil
+}
+
+func lastLine(s string) string {
+	return s[strings.LastIndex(s, "\n")+1:]
+}
@@ -0,0 +1,106 @@
+package installer
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func serverPort(t *testing.T, url string) int {
+	t.Helper()
+	port, err := strconv.Atoi(url[strings.LastIndex(url, ":")+1:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return port
+}
+
+func TestProbeCheck(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			w.WriteHeader(http.StatusOK)
+		case "/login":
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+	port := serverPort(t, srv.URL)
+
+	// A port nothing listens on
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+
+	tests := []struct {
+		name  string
+		probe Probe
+		ready bool
+	}{
+		{"http ok", Probe{HTTP: "/health", Port: port}, true},
+		{"http client error counts as up", Probe{HTTP: "/login", Port: port}, true},
+		{"http server error", Probe{HTTP: "/", Port: port}, false},
+		{"tcp open", Probe{Port: port}, true},
+		{"tcp closed", Probe{Port: closed}, false},
+		{"command ok", Probe{Command: []string{"go", "version"}}, true},
+		{"command fails", Probe{Command: []string{"go", "no-such-command"}}, false},
+	}
+	for _, tt := range tests {
+		err := tt.probe.Check()
+		if (err == nil) != tt.ready {
+			t.Errorf("%s: Check() = %v, want ready %v", tt.name, err, tt.ready)
+		}
+	}
+}
+
+func TestWaitReadyStopsWhenNotAlive(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+
+	exited := errors.New("container exited")
+	start := time.Now()
+	err = WaitReady(Probe{Port: port}, time.Minute, func() error { return exited })
+	if !errors.Is(err, exited) {
+		t.Errorf("WaitReady() = %v, want %v", err, exited)
+	}
+	if time.Since(start) > 10*time.Second {
+		t.Errorf("WaitReady() did not stop early")
+	}
+}
+
+func TestComposeContainerFailed(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"/dify-api-1 running 0 0", ""},
+		{"/dify-init-1 exited 0 0", ""}, // init job
+		{"/dify-db-1 exited 1 0", "container dify-db-1 exited with code 1"},
+		{"/dify-api-1 restarting 1 3", "container dify-api-1 is restarting"},
+		{"/dify-api-1 running 0 2", "container dify-api-1 is restarting"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if err := composeContainerFailed(tt.line); err != nil {
+			got = err.Error()
+		}
+		if got != tt.want {
+			t.Errorf("composeContainerFailed(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
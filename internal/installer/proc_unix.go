@@ -0,0 +1,29 @@
+//go:build !windows
+
+package installer
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// detach starts the process in its own session so it keeps running after
+// the terminal that started it is closed
+func detach(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+}
+
+// processAlive reports whether a process with the given ID exists
+func processAlive(pid int) bool {
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return proc.Signal(syscall.Signal(0)) == nil
+}
+
+// terminate asks the process to shut down gracefully
+func terminate(proc *os.Process) error {
+	return proc.Signal(syscall.SIGTERM)
+}
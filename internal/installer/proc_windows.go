@@ -0,0 +1,34 @@
+//go:build windows
+
+package installer
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+// detach starts the process in a new process group without a console
+// window so it keeps running after getoai exits
+func detach(cmd *exec.Cmd) {
+	const createNewProcessGroup = 0x00000200
+	const detachedProcess = 0x00000008
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: createNewProcessGroup | detachedProcess}
+}
+
+// processAlive reports whether a process with the given ID exists
+func processAlive(pid int) bool {
+	out, err := exec.Command("tasklist", "/FI", fmt.Sprintf("PID eq %d", pid), "/NH").Output()
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(out), fmt.Sprintf(" %d ", pid))
+}
+
+// terminate stops the process. Windows has no SIGTERM equivalent for
+// console servers, so it is killed right away.
+func terminate(proc *os.Process) error {
+	return proc.Kill()
+}
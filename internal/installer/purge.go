@@ -0,0 +1,142 @@
+package installer
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// PathSize returns the total size of the files under path, or -1 if it
+// can't be read
+func PathSize(path string) int64 {
+	var total int64
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			if info, err := d.Info(); err == nil {
+				total += info.Size()
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return -1
+	}
+	return total
+}
+
+// VolumeSizes returns the volumes on the engine with their size, -1 where
+// the engine doesn't report one
+func VolumeSizes() map[string]int64 {
+	out, err := RunCommandSilent(containerCLI(), "volume", "ls", "-q")
+	if err != nil {
+		return nil
+	}
+	sizes := make(map[string]int64)
+	for _, name := range strings.Fields(out) {
+		sizes[name] = -1
+	}
+	if out, err := RunCommandSilent(containerCLI(), "system", "df", "-v"); err == nil {
+		for name, size := range parseVolumeUsage(out) {
+			if _, ok := sizes[name]; ok {
+				sizes[name] = size
+			}
+		}
+	}
+	return sizes
+}
+
+// parseVolumeUsage reads the volume table of `docker system df -v`:
+//
+//	VOLUME NAME   LINKS   SIZE
+//	ollama        1       4.2GB
+func parseVolumeUsage(out string) map[string]int64 {
+	sizes := make(map[string]int64)
+	inTable := false
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		switch {
+		case strings.HasPrefix(line, "VOLUME NAME"):
+			inTable = true
+		case !inTable:
+		case len(fields) == 0:
+			inTable = false
+		case len(fields) >= 2:
+			sizes[fields[0]] = parseHumanSize(fields[len(fields)-1])
+		}
+	}
+	return sizes
+}
+
+// parseHumanSize parses sizes as the engine prints them ("0B", "12.5kB",
+// "4.2GB", "1.1GiB"), returning -1 if it can't
+func parseHumanSize(s string) int64 {
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i <= 0 {
+		return -1
+	}
+	n, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil {
+		return -1
+	}
+	units := map[string]float64{
+		"b":  1,
+		"kb": 1e3, "mb": 1e6, "gb": 1e9, "tb": 1e12,
+		"kib": 1 << 10, "mib": 1 << 20, "gib": 1 << 30, "tib": 1 << 40,
+	}
+	unit, ok := units[strings.ToLower(s[i:])]
+	if !ok {
+		return -1
+	}
+	return int64(n * unit)
+}
+
+// ImageSize returns the size of a local image, or -1 if it isn't present
+func ImageSize(image string) int64 {
+	out, err := RunCommandSilent(containerCLI(), "image", "inspect", "-f", "{{.Size}}", image)
+	if err != nil {
+		return -1
+	}
+	size, err := strconv.ParseInt(strings.TrimSpace(out), 10, 64)
+	if err != nil {
+		return -1
+	}
+	return size
+}
+
+// RemoveVolume deletes a volume and its data
+func RemoveVolume(name string) error {
+	if out, err := RunCommandSilent(containerCLI(), "volume", "rm", name); err != nil {
+		return fmt.Errorf("%s", lastLine(strings.TrimSpace(out)))
+	}
+	return nil
+}
+
+// RemoveImage deletes a local image. It fails if a container still uses it.
+func RemoveImage(image string) error {
+	if out, err := RunCommandSilent(containerCLI(), "rmi", image); err != nil {
+		return fmt.Errorf("%s", lastLine(strings.TrimSpace(out)))
+	}
+	return nil
+}
+
+// RemovePath deletes a file or directory. Files owned by a container's
+// user, which the current user can't delete, are removed with a helper
+// container.
+func RemovePath(path string) error {
+	err := os.RemoveAll(path)
+	if err == nil || !os.IsPermission(err) {
+		return err
+	}
+	if helperErr := helperTar(path, nil, nil, "sh", "-c", "find /data -mindepth 1 -delete"); helperErr != nil {
+		return err
+	}
+	return os.RemoveAll(path)
+}
@@ -0,0 +1,72 @@
+package installer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseHumanSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"0B", 0},
+		{"512B", 512},
+		{"12.5kB", 12500},
+		{"4.2GB", 4200000000},
+		{"1GiB", 1 << 30},
+		{"1.5MiB", 3 << 19},
+		{"N/A", -1},
+		{"12XB", -1},
+		{"", -1},
+	}
+	for _, tt := range tests {
+		if got := parseHumanSize(tt.in); got != tt.want {
+			t.Errorf("parseHumanSize(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseVolumeUsage(t *testing.T) {
+	out := `Images space usage:
+
+REPOSITORY                        TAG       IMAGE ID       CREATED       SIZE      SHARED SIZE   UNIQUE SIZE   CONTAINERS
+ghcr.io/open-webui/open-webui     main      1a2b3c4d5e6f   2 weeks ago   4.1GB     0B            4.1GB         1
+
+Local Volumes space usage:
+
+VOLUME NAME       LINKS     SIZE
+open-webui-data   1         12.5MB
+dify_db           0         N/A
+
+Build cache usage: 0B
+`
+	got := parseVolumeUsage(out)
+	want := map[string]int64{"open-webui-data": 12500000, "dify_db": -1}
+	if len(got) != len(want) {
+		t.Fatalf("parseVolumeUsage() = %v, want %v", got, want)
+	}
+	for name, size := range want {
+		if got[name] != size {
+			t.Errorf("size of %s = %d, want %d", name, got[name], size)
+		}
+	}
+}
+
+func TestPathSize(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "sub"), 0755)
+	os.WriteFile(filepath.Join(dir, "a"), make([]byte, 100), 0600)
+	os.WriteFile(filepath.Join(dir, "sub", "b"), make([]byte, 23), 0600)
+
+	if got := PathSize(dir); got != 123 {
+		t.Errorf("PathSize(dir) = %d, want 123", got)
+	}
+	if got := PathSize(filepath.Join(dir, "a")); got != 100 {
+		t.Errorf("PathSize(file) = %d, want 100", got)
+	}
+	if got := PathSize(filepath.Join(dir, "missing")); got != -1 {
+		t.Errorf("PathSize(missing) = %d, want -1", got)
+	}
+}
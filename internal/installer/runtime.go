@@ -0,0 +1,222 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/getoai/getoai-cli/internal/config"
+	"github.com/getoai/getoai-cli/internal/platform"
+)
+
+// ContainerRuntime is a docker-compatible container engine. All supported
+// runtimes accept the docker CLI syntax for the commands getoai uses (run,
+// ps, inspect, logs, ...), so they differ only in the binary, how to check
+// they are usable, and how compose is invoked.
+type ContainerRuntime interface {
+	// Name returns the runtime name: "docker", "podman" or "nerdctl"
+	Name() string
+	// Command returns the CLI binary
+	Command() string
+	// Check reports whether the runtime is installed and ready, with an
+	// error explaining how to fix it otherwise
+	Check() error
+	// Compose returns the command prefix for compose, e.g. ["docker",
+	// "compose"], or nil if no compose implementation is installed
+	Compose() []string
+	// StorageDir returns the host directory images and volumes are stored
+	// in, or "" if it is unknown or inside a VM
+	StorageDir() string
+}
+
+// ContainerRuntimes lists the supported runtime names
+func ContainerRuntimes() []string {
+	return []string{"docker", "podman", "nerdctl"}
+}
+
+// NewContainerRuntime returns the runtime with the given name
+func NewContainerRuntime(name string) (ContainerRuntime, error) {
+	switch name {
+	case "docker":
+		return dockerRuntime{}, nil
+	case "podman":
+		return podmanRuntime{}, nil
+	case "nerdctl":
+		return nerdctlRuntime{}, nil
+	}
+	return nil, fmt.Errorf("unknown container runtime %q (supported: %s)", name, strings.Join(ContainerRuntimes(), ", "))
+}
+
+// Runtime returns the container runtime to use: the container_runtime
+// config key if set, otherwise the one detected on this system, falling
+// back to docker
+func Runtime() ContainerRuntime {
+	if cfg := config.Get(); cfg != nil && cfg.ContainerRuntime != "" {
+		if rt, err := NewContainerRuntime(cfg.ContainerRuntime); err == nil {
+			return rt
+		}
+	}
+	if rt, err := NewContainerRuntime(platform.Detect().ContainerRuntime); err == nil {
+		return rt
+	}
+	return dockerRuntime{}
+}
+
+// runtimeError explains why a container runtime can't be used and how to
+// fix it
+type runtimeError struct {
+	problem string
+	help    string
+}
+
+func (e *runtimeError) Error() string { return e.problem }
+
+// CheckContainerRuntime checks that the selected container runtime is
+// installed and running, printing instructions if it isn't
+func CheckContainerRuntime() bool {
+	err := Runtime().Check()
+	if err == nil {
+		return true
+	}
+	fmt.Println()
+	fmt.Printf("\033[33m%s\033[0m\n", err)
+	fmt.Println()
+	if rerr, ok := err.(*runtimeError); ok && rerr.help != "" {
+		fmt.Println(rerr.help)
+		fmt.Println()
+	}
+	return false
+}
+
+// containerCLI returns the binary of the selected container runtime
+func containerCLI() string {
+	return Runtime().Command()
+}
+
+// checkInfo runs `<runtime> info` and returns its output on failure
+func checkInfo(bin string) (string, bool) {
+	if _, err := exec.LookPath(bin); err != nil {
+		return "", false
+	}
+	out, err := exec.Command(bin, "info").CombinedOutput()
+	return strings.TrimSpace(string(out)), err == nil
+}
+
+type dockerRuntime struct{}
+
+func (dockerRuntime) Name() string    { return "docker" }
+func (dockerRuntime) Command() string { return "docker" }
+
+func (dockerRuntime) Check() error {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return &runtimeError{"Docker is not installed.", "Install Docker first:\n  getoai install docker\n\nOr install manually from: https://www.docker.com"}
+	}
+	out, ok := checkInfo("docker")
+	if ok {
+		return nil
+	}
+	// Check for common error messages
+	if strings.Contains(out, "Cannot connect to the Docker daemon") ||
+		strings.Contains(out, "Is the docker daemon running") ||
+		strings.Contains(out, "permission denied") {
+		return &runtimeError{"Docker is installed but not running.", "Please start Docker Desktop or the Docker service:\n\n  macOS/Windows: Start Docker Desktop application\n  Linux:         sudo systemctl start docker"}
+	}
+	return &runtimeError{"Docker is installed but not running.", "Error: " + out}
+}
+
+func (dockerRuntime) Compose() []string {
+	// Try docker compose (v2) first
+	out, _ := exec.Command("docker", "compose", "version").CombinedOutput()
+	if strings.Contains(string(out), "Docker Compose") {
+		return []string{"docker", "compose"}
+	}
+	// Fallback to docker-compose (v1)
+	if _, err := exec.LookPath("docker-compose"); err == nil {
+		return []string{"docker-compose"}
+	}
+	return nil
+}
+
+func (dockerRuntime) StorageDir() string {
+	return infoDir("docker", "{{.DockerRootDir}}")
+}
+
+type podmanRuntime struct{}
+
+func (podmanRuntime) Name() string    { return "podman" }
+func (podmanRuntime) Command() string { return "podman" }
+
+func (podmanRuntime) Check() error {
+	if _, err := exec.LookPath("podman"); err != nil {
+		return &runtimeError{"Podman is not installed.", "Install it from: https://podman.io/docs/installation"}
+	}
+	out, ok := checkInfo("podman")
+	if ok {
+		return nil
+	}
+	if platform.Detect().OS != "linux" {
+		return &runtimeError{"Podman is installed but its machine is not running.", "Start it with:\n  podman machine init   # first time only\n  podman machine start"}
+	}
+	return &runtimeError{"Podman is installed but not working.", "Error: " + out}
+}
+
+func (podmanRuntime) Compose() []string {
+	// podman compose (4.7+) delegates to docker-compose or podman-compose
+	if err := exec.Command("podman", "compose", "version").Run(); err == nil {
+		return []string{"podman", "compose"}
+	}
+	if _, err := exec.LookPath("podman-compose"); err == nil {
+		return []string{"podman-compose"}
+	}
+	return nil
+}
+
+func (podmanRuntime) StorageDir() string {
+	return infoDir("podman", "{{.Store.GraphRoot}}")
+}
+
+type nerdctlRuntime struct{}
+
+func (nerdctlRuntime) Name() string    { return "nerdctl" }
+func (nerdctlRuntime) Command() string { return "nerdctl" }
+
+func (nerdctlRuntime) Check() error {
+	if _, err := exec.LookPath("nerdctl"); err != nil {
+		return &runtimeError{"nerdctl is not installed.", "Install it from: https://github.com/containerd/nerdctl/releases"}
+	}
+	out, ok := checkInfo("nerdctl")
+	if ok {
+		return nil
+	}
+	return &runtimeError{"nerdctl is installed but containerd is not reachable.", "Start containerd:\n  sudo systemctl start containerd\n  containerd-rootless-setuptool.sh install   # for rootless mode\n\nError: " + out}
+}
+
+func (nerdctlRuntime) Compose() []string {
+	// Compose support is built into nerdctl
+	return []string{"nerdctl", "compose"}
+}
+
+func (nerdctlRuntime) StorageDir() string {
+	// nerdctl info doesn't report the containerd root
+	return ""
+}
+
+// infoDir returns the directory a runtime reports with format in its info,
+// if it exists on the host: engines running in a VM (Docker Desktop,
+// podman machine) report a path inside the VM
+func infoDir(bin, format string) string {
+	out, err := RunCommandSilent(bin, "info", "-f", format)
+	if err != nil {
+		return ""
+	}
+	dir := strings.TrimSpace(out)
+	if !filepath.IsAbs(dir) {
+		return ""
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return ""
+	}
+	return dir
+}
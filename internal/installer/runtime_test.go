@@ -0,0 +1,30 @@
+package installer
+
+import "testing"
+
+func TestNewContainerRuntime(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		wantErr bool
+	}{
+		{"docker", "docker", false},
+		{"podman", "podman", false},
+		{"nerdctl", "nerdctl", false},
+		{"", "", true},
+		{"lxc", "", true},
+	}
+	for _, tt := range tests {
+		rt, err := NewContainerRuntime(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NewContainerRuntime(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if rt.Name() != tt.name || rt.Command() != tt.command {
+			t.Errorf("NewContainerRuntime(%q) = %s/%s, want %s/%s", tt.name, rt.Name(), rt.Command(), tt.name, tt.command)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/getoai/getoai-cli/internal/platform"
+)
+
+// DefaultRestart is the restart policy of service units that don't declare one
+const DefaultRestart = "on-failure"
+
+// RestartPolicies are the Restart= values systemd accepts
+var RestartPolicies = []string{"no", "always", "on-success", "on-failure", "on-abnormal", "on-abort", "on-watchdog"}
+
+// SystemdUnit is a systemd user service running a tool's server, so it
+// starts at login and is restarted by systemd when it fails
+type SystemdUnit struct {
+	Name        string            // tool name, the unit is getoai-<name>.service
+	Description string            // shown by systemctl status
+	Command     []string          // command line, with an absolute path to the binary
+	Env         map[string]string // environment variables
+	Restart     string            // restart policy, DefaultRestart if empty
+	LogFile     string            // file the output is appended to
+}
+
+// UnitName returns the systemd unit name of a tool's service
+func UnitName(name string) string {
+	return "getoai-" + name + ".service"
+}
+
+// UnitPath returns where the user unit of a tool's service is installed
+func UnitPath(name string) string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, _ := os.UserHomeDir()
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "systemd", "user", UnitName(name))
+}
+
+// Render returns the unit file
+func (u SystemdUnit) Render() string {
+	var b strings.Builder
+	b.WriteString("# Generated by getoai, remove with: getoai service disable " + u.Name + "\n")
+	b.WriteString("[Unit]\n")
+	fmt.Fprintf(&b, "Description=%s\n", u.Description)
+	b.WriteString("After=network-online.target\n\n")
+
+	b.WriteString("[Service]\n")
+	quoted := make([]string, len(u.Command))
+	for i, arg := range u.Command {
+		quoted[i] = systemdQuote(strings.ReplaceAll(systemdEscape(arg), "$", "$$"))
+	}
+	fmt.Fprintf(&b, "ExecStart=%s\n", strings.Join(quoted, " "))
+	for _, key := range sortedKeys(u.Env) {
+		fmt.Fprintf(&b, "Environment=%s\n", systemdQuote(systemdEscape(key+"="+u.Env[key])))
+	}
+	restart := u.Restart
+	if restart == "" {
+		restart = DefaultRestart
+	}
+	fmt.Fprintf(&b, "Restart=%s\nRestartSec=5\n", restart)
+	if u.LogFile != "" {
+		// Keeps 'getoai logs' working the same as for servers started by getoai
+		fmt.Fprintf(&b, "StandardOutput=append:%s\nStandardError=append:%s\n", systemdEscape(u.LogFile), systemdEscape(u.LogFile))
+	}
+	b.WriteString("\n[Install]\nWantedBy=default.target\n")
+	return b.String()
+}
+
+// systemdQuote quotes an ExecStart argument or Environment assignment if
+// it needs it
+func systemdQuote(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\"'\\;") {
+		return s
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// systemdEscape escapes % specifiers, which systemd expands in unit
+// settings. ExecStart also expands $ variables.
+func systemdEscape(s string) string {
+	return strings.ReplaceAll(s, "%", "%%")
+}
+
+// CheckSystemd returns an error if systemd user services can't be used
+// on this machine
+func CheckSystemd() error {
+	if runtime.GOOS != "linux" {
+		return fmt.Errorf("services are managed with systemd, which %s doesn't have", runtime.GOOS)
+	}
+	if platform.Detect().IsWSL {
+		if _, err := os.Stat("/run/systemd/system"); err != nil {
+			return fmt.Errorf("systemd is not enabled in WSL, set systemd=true under [boot] in /etc/wsl.conf")
+		}
+	}
+	if _, err := exec.LookPath("systemctl"); err != nil {
+		return fmt.Errorf("systemctl not found")
+	}
+	if _, err := RunCommandSilent("systemctl", "--user", "show-environment"); err != nil {
+		return fmt.Errorf("the systemd user manager is not running")
+	}
+	return nil
+}
+
+// InstallUnit writes the unit, then enables and starts it
+func InstallUnit(u SystemdUnit) error {
+	path := UnitPath(u.Name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if u.LogFile != "" {
+		if err := os.MkdirAll(filepath.Dir(u.LogFile), 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, []byte(u.Render()), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := systemctl("daemon-reload"); err != nil {
+		return err
+	}
+	return systemctl("enable", "--now", UnitName(u.Name))
+}
+
+// RemoveUnit stops and disables a tool's service unit and deletes it
+func RemoveUnit(name string) error {
+	_ = systemctl("disable", "--now", UnitName(name))
+	if err := os.Remove(UnitPath(name)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return systemctl("daemon-reload")
+}
+
+// UnitInstalled reports whether a tool's server runs as a systemd unit
+func UnitInstalled(name string) bool {
+	if runtime.GOOS != "linux" {
+		return false
+	}
+	_, err := os.Stat(UnitPath(name))
+	return err == nil
+}
+
+// UnitPID returns the main process ID of a tool's service unit, 0 if it
+// isn't running
+func UnitPID(name string) int {
+	out, err := RunCommandSilent("systemctl", "--user", "show", "-p", "MainPID", "--value", UnitName(name))
+	if err != nil {
+		return 0
+	}
+	pid, _ := strconv.Atoi(strings.TrimSpace(out))
+	return pid
+}
+
+// StartUnit starts a tool's service unit
+func StartUnit(name string) error {
+	return systemctl("start", UnitName(name))
+}
+
+// StopUnit stops a tool's service unit. It stays enabled and starts again
+// at the next login.
+func StopUnit(name string) error {
+	return systemctl("stop", UnitName(name))
+}
+
+// LingerEnabled reports whether the user's services keep running after
+// they log out
+func LingerEnabled() bool {
+	out, err := RunCommandSilent("loginctl", "show-user", strconv.Itoa(os.Getuid()), "-p", "Linger", "--value")
+	return err == nil && strings.TrimSpace(out) == "yes"
+}
+
+// ValidRestart reports whether policy is a restart policy systemd accepts
+func ValidRestart(policy string) bool {
+	return slices.Contains(RestartPolicies, policy)
+}
+
+func systemctl(args ...string) error {
+	out, err := RunCommandSilent("systemctl", append([]string{"--user"}, args...)...)
+	if err != nil {
+		if msg := strings.TrimSpace(out); msg != "" {
+			return fmt.Errorf("systemctl %s: %s", args[0], lastLine(msg))
+		}
+		return fmt.Errorf("systemctl %s: %w", args[0], err)
+	}
+	return nil
+}
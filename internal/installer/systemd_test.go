@@ -0,0 +1,61 @@
+package installer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSystemdQuote(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"--port", "--port"},
+		{"/tmp/my models/q.gguf", `"/tmp/my models/q.gguf"`},
+		{`say "hi"`, `"say \"hi\""`},
+		{`C:\models`, `"C:\\models"`},
+		{"a;b", `"a;b"`},
+		{"", `""`},
+	}
+	for _, tt := range tests {
+		if got := systemdQuote(tt.in); got != tt.want {
+			t.Errorf("systemdQuote(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSystemdUnitRender(t *testing.T) {
+	u := SystemdUnit{
+		Name:        "llama-cpp",
+		Description: "llama-cpp server (getoai)",
+		Command:     []string{"/usr/local/bin/llama-server", "--port", "8080", "-m", "/models/my model.gguf", "--alias", "$HOME 100%"},
+		Env:         map[string]string{"PATH": "/usr/bin", "GREETING": "50% $off"},
+		LogFile:     "/home/me/.getoai/logs/llama-cpp.log",
+	}
+	want := `# Generated by getoai, remove with: getoai service disable llama-cpp
+[Unit]
+Description=llama-cpp server (getoai)
+After=network-online.target
+
+[Service]
+ExecStart=/usr/local/bin/llama-server --port 8080 -m "/models/my model.gguf" --alias "$$HOME 100%%"
+Environment="GREETING=50%% $off"
+Environment=PATH=/usr/bin
+Restart=on-failure
+RestartSec=5
+StandardOutput=append:/home/me/.getoai/logs/llama-cpp.log
+StandardError=append:/home/me/.getoai/logs/llama-cpp.log
+
+[Install]
+WantedBy=default.target
+`
+	if got := u.Render(); got != want {
+		t.Errorf("Render() =\n%s\nwant:\n%s", got, want)
+	}
+
+	u.Restart = "always"
+	u.LogFile = ""
+	got := u.Render()
+	if !strings.Contains(got, "Restart=always\n") || strings.Contains(got, "StandardOutput") {
+		t.Errorf("Render() with Restart=always and no log file =\n%s", got)
+	}
+}
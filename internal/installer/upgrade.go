@@ -0,0 +1,279 @@
+package installer
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// previousSuffix names the container kept after an upgrade for rollback
+const previousSuffix = "-previous"
+
+// PreviousContainerName returns the name of the container kept by
+// UpgradeContainer so it can be rolled back to
+func PreviousContainerName(containerName string) string {
+	return containerName + previousSuffix
+}
+
+// containerInspect is the part of `docker inspect` output needed to
+// recreate a container
+type containerInspect struct {
+	Name   string `json:"Name"`
+	Image  string `json:"Image"` // image ID
+	Config struct {
+		Image  string            `json:"Image"`
+		Env    []string          `json:"Env"`
+		Labels map[string]string `json:"Labels"`
+	} `json:"Config"`
+	HostConfig struct {
+		Binds        []string `json:"Binds"`
+		NetworkMode  string   `json:"NetworkMode"`
+		ExtraHosts   []string `json:"ExtraHosts"`
+		PortBindings map[string][]struct {
+			HostIP   string `json:"HostIp"`
+			HostPort string `json:"HostPort"`
+		} `json:"PortBindings"`
+	} `json:"HostConfig"`
+	Mounts []struct {
+		Type        string `json:"Type"`
+		Name        string `json:"Name"`
+		Source      string `json:"Source"`
+		Destination string `json:"Destination"`
+	} `json:"Mounts"`
+}
+
+// imageInspect is the part of `docker image inspect` output holding the
+// defaults baked into an image
+type imageInspect struct {
+	Config struct {
+		Env    []string          `json:"Env"`
+		Labels map[string]string `json:"Labels"`
+	} `json:"Config"`
+}
+
+// InspectContainer reads the settings of an existing container: its
+// ports, volumes, and the env and labels that were set on top of the
+// image defaults
+func InspectContainer(containerName string) (*ContainerSpec, error) {
+	out, err := RunCommandSilent(containerCLI(), "inspect", "--type", "container", containerName)
+	if err != nil {
+		return nil, fmt.Errorf("container %s not found", containerName)
+	}
+	var containers []containerInspect
+	if err := json.Unmarshal([]byte(out), &containers); err != nil || len(containers) == 0 {
+		return nil, fmt.Errorf("failed to inspect container %s", containerName)
+	}
+	c := containers[0]
+
+	var image imageInspect
+	if out, err := RunCommandSilent(containerCLI(), "image", "inspect", c.Image); err == nil {
+		var images []imageInspect
+		if json.Unmarshal([]byte(out), &images) == nil && len(images) > 0 {
+			image = images[0]
+		}
+	}
+
+	return specFromInspect(c, image), nil
+}
+
+func specFromInspect(c containerInspect, image imageInspect) *ContainerSpec {
+	spec := &ContainerSpec{
+		Name:  strings.TrimPrefix(c.Name, "/"),
+		Image: c.Config.Image,
+	}
+
+	// Ports, sorted by container port for a stable order
+	containerPorts := make([]string, 0, len(c.HostConfig.PortBindings))
+	for port := range c.HostConfig.PortBindings {
+		containerPorts = append(containerPorts, port)
+	}
+	sort.Strings(containerPorts)
+	for _, port := range containerPorts {
+		target := strings.TrimSuffix(port, "/tcp")
+		for _, b := range c.HostConfig.PortBindings[port] {
+			switch {
+			case b.HostPort == "":
+				spec.Ports = append(spec.Ports, target)
+			case b.HostIP == "" || b.HostIP == "0.0.0.0":
+				spec.Ports = append(spec.Ports, b.HostPort+":"+target)
+			default:
+				spec.Ports = append(spec.Ports, b.HostIP+":"+b.HostPort+":"+target)
+			}
+		}
+	}
+
+	// Env set on the container, without the image's own defaults
+	imageEnv := make(map[string]bool)
+	for _, e := range image.Config.Env {
+		imageEnv[e] = true
+	}
+	for _, e := range c.Config.Env {
+		if imageEnv[e] {
+			continue
+		}
+		if key, value, ok := strings.Cut(e, "="); ok {
+			if spec.Env == nil {
+				spec.Env = make(map[string]string)
+			}
+			spec.Env[key] = value
+		}
+	}
+
+	// Labels set on the container, without the image's own labels
+	for k, v := range c.Config.Labels {
+		if iv, ok := image.Config.Labels[k]; ok && iv == v {
+			continue
+		}
+		if spec.Labels == nil {
+			spec.Labels = make(map[string]string)
+		}
+		spec.Labels[k] = v
+	}
+
+	// A user-defined network; the engine's defaults need no flag
+	switch c.HostConfig.NetworkMode {
+	case "", "default", "bridge":
+	default:
+		spec.Network = c.HostConfig.NetworkMode
+	}
+	spec.Hosts = append(spec.Hosts, c.HostConfig.ExtraHosts...)
+
+	// Explicit volume mappings, then every other mount, including the
+	// anonymous volumes declared by the image, so no data is left behind
+	spec.Volumes = append(spec.Volumes, c.HostConfig.Binds...)
+	mounted := make(map[string]bool)
+	for _, b := range c.HostConfig.Binds {
+		mounted[VolumeTarget(b)] = true
+	}
+	for _, m := range c.Mounts {
+		if mounted[m.Destination] {
+			continue
+		}
+		switch m.Type {
+		case "volume":
+			spec.Volumes = append(spec.Volumes, m.Name+":"+m.Destination)
+		case "bind":
+			spec.Volumes = append(spec.Volumes, m.Source+":"+m.Destination)
+		}
+	}
+
+	return spec
+}
+
+// UpgradeContainer pulls image and recreates the container from its
+// current settings. The old container is stopped and kept under its
+// previous name until the next upgrade, so RollbackContainer can switch
+// back. The returned spec describes the new container.
+func (d *DockerInstaller) UpgradeContainer(containerName, image string) (*ContainerSpec, error) {
+	if !CheckContainerRuntime() {
+		return nil, fmt.Errorf("%s is required but not running", Runtime().Name())
+	}
+
+	spec, err := InspectContainer(containerName)
+	if err != nil {
+		return nil, err
+	}
+	spec.Image = image
+
+	fmt.Printf("Pulling image %s...\n", image)
+	if err := d.PullImage(image); err != nil {
+		showDockerMirrorHelp()
+		return nil, &PullError{Image: image, Err: err}
+	}
+
+	previous := PreviousContainerName(containerName)
+	_, _ = d.RunCommandSilent(containerCLI(), "rm", "-f", previous)
+	if _, err := d.RunCommandSilent(containerCLI(), "stop", containerName); err != nil {
+		return nil, fmt.Errorf("failed to stop %s: %w", containerName, err)
+	}
+	if _, err := d.RunCommandSilent(containerCLI(), "rename", containerName, previous); err != nil {
+		_, _ = d.RunCommandSilent(containerCLI(), "start", containerName)
+		return nil, fmt.Errorf("failed to keep the old container: %w", err)
+	}
+
+	if err := d.RunContainer(*spec); err != nil {
+		// Put the old container back right away
+		_, _ = d.RunCommandSilent(containerCLI(), "rm", "-f", containerName)
+		if _, rerr := d.RunCommandSilent(containerCLI(), "rename", previous, containerName); rerr == nil {
+			_, _ = d.RunCommandSilent(containerCLI(), "start", containerName)
+		}
+		return nil, err
+	}
+
+	fmt.Println("Waiting for the new container to become healthy...")
+	if err := WaitHealthy(containerName, 60*time.Second); err != nil {
+		fmt.Println()
+		_ = d.ContainerLogs(containerName, false, 20)
+		return spec, fmt.Errorf("new container failed its health check: %w", err)
+	}
+
+	fmt.Printf("\033[32m✓ Container '%s' upgraded, the old one is kept stopped as '%s'\033[0m\n", containerName, previous)
+	return spec, nil
+}
+
+// RollbackContainer replaces the container with the one kept by the last
+// upgrade and starts it
+func (d *DockerInstaller) RollbackContainer(containerName string) error {
+	previous := PreviousContainerName(containerName)
+	if ContainerState(previous) == "" {
+		return fmt.Errorf("no previous container to roll back to")
+	}
+
+	if _, err := d.RunCommandSilent(containerCLI(), "rm", "-f", containerName); err != nil && ContainerState(containerName) != "" {
+		return fmt.Errorf("failed to remove %s: %w", containerName, err)
+	}
+	if _, err := d.RunCommandSilent(containerCLI(), "rename", previous, containerName); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", previous, err)
+	}
+	return d.StartContainer(containerName)
+}
+
+// WaitHealthy waits for a container to be running and, if its image has a
+// HEALTHCHECK, healthy. A container without a health check counts as
+// healthy once it has kept running for a few seconds.
+func WaitHealthy(containerName string, timeout time.Duration) error {
+	const settle = 5 * time.Second
+	deadline := time.Now().Add(timeout)
+	var runningSince time.Time
+
+	for time.Now().Before(deadline) {
+		out, err := RunCommandSilent(containerCLI(), "inspect", "-f",
+			"{{.State.Status}} {{.RestartCount}} {{if .State.Health}}{{.State.Health.Status}}{{end}}", containerName)
+		if err != nil {
+			return fmt.Errorf("container %s not found", containerName)
+		}
+		fields := strings.Fields(out)
+		if len(fields) == 0 {
+			time.Sleep(time.Second)
+			continue
+		}
+		status, restarts, health := fields[0], "0", ""
+		if len(fields) > 1 {
+			restarts = fields[1]
+		}
+		if len(fields) > 2 {
+			health = fields[2]
+		}
+
+		switch {
+		case status == "exited" || status == "dead":
+			return fmt.Errorf("container %s", status)
+		case restarts != "0":
+			return fmt.Errorf("container is restarting")
+		case health == "unhealthy":
+			return fmt.Errorf("container is unhealthy")
+		case health == "healthy":
+			return nil
+		case status == "running" && health == "":
+			if runningSince.IsZero() {
+				runningSince = time.Now()
+			} else if time.Since(runningSince) >= settle {
+				return nil
+			}
+		}
+		time.Sleep(time.Second)
+	}
+	return fmt.Errorf("timed out after %s", timeout)
+}
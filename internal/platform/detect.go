@@ -1,30 +1,52 @@
 package platform
 
 import (
+	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"strings"
 )
 
 type Platform struct {
-	OS        string
-	Arch      string
-	HasBrew   bool
-	HasApt    bool
-	HasYum    bool
-	HasDnf    bool
-	HasPacman bool
-	HasChoco  bool
-	HasScoop  bool
-	HasNpm    bool
-	HasPip    bool
-	HasPip3   bool
-	HasDocker bool
-	HasGo     bool
-	HasCurl   bool
-	HasWget   bool
-	HomeDir   string
-	IsWSL     bool
+	OS         string
+	Arch       string
+	HasBrew    bool
+	HasApt     bool
+	HasYum     bool
+	HasDnf     bool
+	HasPacman  bool
+	HasChoco   bool
+	HasScoop   bool
+	HasNpm     bool
+	HasPip     bool
+	HasPip3    bool
+	HasDocker  bool
+	HasPodman  bool
+	HasNerdctl bool
+	HasGo      bool
+	HasCurl    bool
+	HasWget    bool
+	HomeDir    string
+	IsWSL      bool
+
+	// ContainerRuntime is the first container engine found: "docker",
+	// "podman" or "nerdctl", empty if none is installed
+	ContainerRuntime string
+
+	// Linux distribution facts (empty on other systems)
+	Distro        string   // ID from /etc/os-release, e.g. "ubuntu", "fedora", "alpine"
+	DistroVersion string   // VERSION_ID from /etc/os-release, e.g. "22.04"
+	DistroLike    []string // ID_LIKE from /etc/os-release, e.g. ["debian"]
+	Libc          string   // "glibc" or "musl"
+
+	// Hardware facts
+	CPUCores    int
+	CPUFeatures []string // SIMD extensions, e.g. "avx2", "neon"
+
+	// Lazily probed facts, see MemoryTotal and RuntimeVersion
+	memoryTotal *uint64
+	runtimes    map[string]string
 }
 
 var current *Platform
@@ -35,9 +57,11 @@ func Detect() *Platform {
 	}
 
 	p := &Platform{
-		OS:   runtime.GOOS,
-		Arch: runtime.GOARCH,
+		OS:       runtime.GOOS,
+		Arch:     runtime.GOARCH,
+		CPUCores: runtime.NumCPU(),
 	}
+	p.HomeDir, _ = os.UserHomeDir()
 
 	p.HasBrew = commandExists("brew")
 	p.HasApt = commandExists("apt-get")
@@ -50,11 +74,31 @@ func Detect() *Platform {
 	p.HasPip = commandExists("pip")
 	p.HasPip3 = commandExists("pip3")
 	p.HasDocker = commandExists("docker")
+	p.HasPodman = commandExists("podman")
+	p.HasNerdctl = commandExists("nerdctl")
 	p.HasGo = commandExists("go")
 	p.HasCurl = commandExists("curl")
 	p.HasWget = commandExists("wget")
 	p.IsWSL = detectWSL()
 
+	switch {
+	case p.HasDocker:
+		p.ContainerRuntime = "docker"
+	case p.HasPodman:
+		p.ContainerRuntime = "podman"
+	case p.HasNerdctl:
+		p.ContainerRuntime = "nerdctl"
+	}
+
+	if p.OS == "linux" {
+		release := readOSRelease()
+		p.Distro = release["ID"]
+		p.DistroVersion = release["VERSION_ID"]
+		p.DistroLike = strings.Fields(release["ID_LIKE"])
+		p.Libc = detectLibc()
+	}
+	p.CPUFeatures = detectCPUFeatures(p.OS, p.Arch)
+
 	current = p
 	return p
 }
@@ -82,6 +126,46 @@ func detectWSL() bool {
 		strings.Contains(strings.ToLower(string(out)), "wsl")
 }
 
+// readOSRelease reads the os-release file of the running Linux system
+func readOSRelease() map[string]string {
+	for _, path := range []string{"/etc/os-release", "/usr/lib/os-release"} {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			return parseOSRelease(string(data))
+		}
+	}
+	return map[string]string{}
+}
+
+// parseOSRelease parses KEY=value lines as found in /etc/os-release
+func parseOSRelease(data string) map[string]string {
+	values := make(map[string]string)
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		values[key] = strings.Trim(value, `"'`)
+	}
+	return values
+}
+
+// detectLibc reports whether the system C library is glibc or musl
+func detectLibc() string {
+	if matches, _ := filepath.Glob("/lib/ld-musl-*.so.1"); len(matches) > 0 {
+		return "musl"
+	}
+	out, _ := exec.Command("ldd", "--version").CombinedOutput()
+	if strings.Contains(strings.ToLower(string(out)), "musl") {
+		return "musl"
+	}
+	return "glibc"
+}
+
 func (p *Platform) GetPackageManager() string {
 	switch p.OS {
 	case "darwin":
@@ -127,3 +211,31 @@ func (p *Platform) IsWindows() bool {
 func (p *Platform) String() string {
 	return p.OS + "/" + p.Arch
 }
+
+// IsDebianLike reports whether this is Debian or a derivative (Ubuntu, Mint, ...)
+func (p *Platform) IsDebianLike() bool {
+	if p.Distro == "debian" {
+		return true
+	}
+	for _, like := range p.DistroLike {
+		if like == "debian" {
+			return true
+		}
+	}
+	return false
+}
+
+// DistroName returns a short description like "ubuntu 22.04 (glibc)"
+func (p *Platform) DistroName() string {
+	if p.Distro == "" {
+		return ""
+	}
+	name := p.Distro
+	if p.DistroVersion != "" {
+		name += " " + p.DistroVersion
+	}
+	if p.Libc != "" {
+		name += " (" + p.Libc + ")"
+	}
+	return name
+}
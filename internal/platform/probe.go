@@ -0,0 +1,228 @@
+package platform
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Runtimes whose versions can be probed with RuntimeVersion
+var runtimeProbes = map[string][][]string{
+	"node":    {{"node", "--version"}},
+	"python":  {{"python3", "--version"}, {"python", "--version"}},
+	"go":      {{"go", "version"}},
+	"docker":  {{"docker", "--version"}},
+	"compose": {{"docker", "compose", "version", "--short"}, {"docker-compose", "--version"}},
+}
+
+var versionPattern = regexp.MustCompile(`\d+(\.\d+)+`)
+
+// RuntimeNames returns the runtimes supported by RuntimeVersion
+func RuntimeNames() []string {
+	return []string{"node", "python", "go", "docker", "compose"}
+}
+
+// RuntimeVersion returns the installed version of a runtime such as "node"
+// or "python", or an empty string if it is not installed. Results are cached
+// because probing spawns a process.
+func (p *Platform) RuntimeVersion(name string) string {
+	if version, ok := p.runtimes[name]; ok {
+		return version
+	}
+
+	version := ""
+	for _, probe := range runtimeProbes[name] {
+		if _, err := exec.LookPath(probe[0]); err != nil {
+			continue
+		}
+		out, err := exec.Command(probe[0], probe[1:]...).CombinedOutput()
+		if err != nil {
+			continue
+		}
+		if version = versionPattern.FindString(string(out)); version != "" {
+			break
+		}
+	}
+
+	if p.runtimes == nil {
+		p.runtimes = make(map[string]string)
+	}
+	p.runtimes[name] = version
+	return version
+}
+
+// MemoryTotal returns the total physical memory in bytes, 0 if unknown
+func (p *Platform) MemoryTotal() uint64 {
+	if p.memoryTotal == nil {
+		total := detectMemoryTotal(p.OS)
+		p.memoryTotal = &total
+	}
+	return *p.memoryTotal
+}
+
+// FreeDisk returns the free disk space in bytes on the filesystem holding
+// path, 0 if unknown. Missing path components are walked up so the target
+// directory doesn't need to exist yet.
+func (p *Platform) FreeDisk(path string) uint64 {
+	for path != "" {
+		if _, err := os.Stat(path); err == nil {
+			break
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			break
+		}
+		path = parent
+	}
+
+	if p.OS == "windows" {
+		drive := strings.TrimSuffix(filepath.VolumeName(path), ":")
+		if drive == "" {
+			drive = "C"
+		}
+		out, err := exec.Command("powershell", "-NoProfile", "-Command", "(Get-PSDrive "+drive+").Free").Output()
+		if err != nil {
+			return 0
+		}
+		free, _ := strconv.ParseUint(strings.TrimSpace(string(out)), 10, 64)
+		return free
+	}
+
+	out, err := exec.Command("df", "-Pk", path).Output()
+	if err != nil {
+		return 0
+	}
+	return parseDfAvailable(string(out))
+}
+
+// parseDfAvailable extracts the available space from `df -Pk` output
+func parseDfAvailable(out string) uint64 {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) < 2 {
+		return 0
+	}
+	fields := strings.Fields(lines[len(lines)-1])
+	if len(fields) < 4 {
+		return 0
+	}
+	kb, err := strconv.ParseUint(fields[3], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return kb * 1024
+}
+
+func detectMemoryTotal(osName string) uint64 {
+	switch osName {
+	case "linux":
+		data, err := os.ReadFile("/proc/meminfo")
+		if err != nil {
+			return 0
+		}
+		return parseMeminfo(string(data))
+	case "darwin":
+		out, err := exec.Command("sysctl", "-n", "hw.memsize").Output()
+		if err != nil {
+			return 0
+		}
+		total, _ := strconv.ParseUint(strings.TrimSpace(string(out)), 10, 64)
+		return total
+	case "windows":
+		out, err := exec.Command("powershell", "-NoProfile", "-Command",
+			"(Get-CimInstance Win32_ComputerSystem).TotalPhysicalMemory").Output()
+		if err != nil {
+			return 0
+		}
+		total, _ := strconv.ParseUint(strings.TrimSpace(string(out)), 10, 64)
+		return total
+	}
+	return 0
+}
+
+// parseMeminfo extracts MemTotal from /proc/meminfo
+func parseMeminfo(data string) uint64 {
+	for _, line := range strings.Split(data, "\n") {
+		if !strings.HasPrefix(line, "MemTotal:") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return 0
+		}
+		kb, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return 0
+		}
+		return kb * 1024
+	}
+	return 0
+}
+
+// detectCPUFeatures reports the SIMD extensions relevant for local inference
+func detectCPUFeatures(osName, arch string) []string {
+	var features []string
+
+	if arch == "arm64" {
+		// Advanced SIMD is mandatory on arm64
+		features = append(features, "neon")
+	}
+
+	switch osName {
+	case "linux":
+		data, err := os.ReadFile("/proc/cpuinfo")
+		if err != nil {
+			return features
+		}
+		features = append(features, parseCPUFlags(string(data), arch)...)
+	case "darwin":
+		if arch != "amd64" {
+			return features
+		}
+		out, _ := exec.Command("sysctl", "-n", "machdep.cpu.leaf7_features").Output()
+		flags := strings.ToLower(string(out))
+		for _, f := range []string{"avx2", "avx512f"} {
+			if strings.Contains(flags, f) {
+				features = append(features, f)
+			}
+		}
+	}
+	return features
+}
+
+// parseCPUFlags extracts interesting x86 flags from /proc/cpuinfo
+func parseCPUFlags(data, arch string) []string {
+	if arch != "amd64" && arch != "386" {
+		return nil
+	}
+	var features []string
+	for _, line := range strings.Split(data, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(key) != "flags" {
+			continue
+		}
+		flags := strings.Fields(value)
+		for _, want := range []string{"avx", "avx2", "avx512f", "fma"} {
+			for _, f := range flags {
+				if f == want {
+					features = append(features, want)
+					break
+				}
+			}
+		}
+		break
+	}
+	return features
+}
+
+// HasCPUFeature reports whether the CPU supports the given feature (e.g. "avx2")
+func (p *Platform) HasCPUFeature(feature string) bool {
+	for _, f := range p.CPUFeatures {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
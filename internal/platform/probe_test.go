@@ -0,0 +1,36 @@
+package platform
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMeminfo(t *testing.T) {
+	data := "MemTotal:       16314480 kB\nMemFree:         1234567 kB\n"
+	if got, want := parseMeminfo(data), uint64(16314480*1024); got != want {
+		t.Errorf("parseMeminfo() = %d, want %d", got, want)
+	}
+	if got := parseMeminfo("garbage"); got != 0 {
+		t.Errorf("parseMeminfo(garbage) = %d, want 0", got)
+	}
+}
+
+func TestParseDfAvailable(t *testing.T) {
+	out := `Filesystem     1024-blocks      Used Available Capacity Mounted on
+/dev/nvme0n1p2   490617784 250000000 215617784      54% /
+`
+	if got, want := parseDfAvailable(out), uint64(215617784*1024); got != want {
+		t.Errorf("parseDfAvailable() = %d, want %d", got, want)
+	}
+}
+
+func TestParseCPUFlags(t *testing.T) {
+	data := "processor\t: 0\nflags\t\t: fpu sse sse2 avx avx2 fma\n"
+	want := []string{"avx", "avx2", "fma"}
+	if got := parseCPUFlags(data, "amd64"); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseCPUFlags() = %v, want %v", got, want)
+	}
+	if got := parseCPUFlags(data, "arm64"); got != nil {
+		t.Errorf("parseCPUFlags(arm64) = %v, want nil", got)
+	}
+}
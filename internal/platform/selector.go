@@ -0,0 +1,116 @@
+package platform
+
+import (
+	"sort"
+	"strings"
+)
+
+// Platform selectors are used as keys in PlatformOverrides and DownloadURLs.
+// A selector has the form "os[/arch][:qualifier]", for example:
+//
+//	darwin            any macOS system
+//	linux/arm64       Linux on arm64
+//	linux:debian      Debian and derivatives (matches ID and ID_LIKE)
+//	linux:musl        Linux with musl libc
+//	linux/amd64:musl  musl Linux on amd64
+//	wsl               Linux running inside WSL
+//
+// When several selectors match, the most specific one wins.
+
+// Selector specificity weights. The architecture is weighted highest because
+// picking a binary for the wrong architecture is never recoverable.
+const (
+	scoreOS        = 1
+	scoreQualifier = 2
+	scoreArch      = 4
+)
+
+// Match reports whether the selector applies to this platform and how
+// specific it is. Higher scores are more specific.
+func (p *Platform) Match(selector string) (int, bool) {
+	selector = strings.ToLower(strings.TrimSpace(selector))
+	if selector == "" {
+		return 0, false
+	}
+
+	base, qualifier, _ := strings.Cut(selector, ":")
+	osName, arch, _ := strings.Cut(base, "/")
+
+	score := scoreOS
+	switch osName {
+	case "wsl":
+		if p.OS != "linux" || !p.IsWSL {
+			return 0, false
+		}
+		score += scoreQualifier
+	case p.OS:
+	default:
+		return 0, false
+	}
+
+	if arch != "" {
+		if !p.matchArch(arch) {
+			return 0, false
+		}
+		score += scoreArch
+	}
+
+	if qualifier != "" {
+		if !p.matchQualifier(qualifier) {
+			return 0, false
+		}
+		score += scoreQualifier
+	}
+
+	return score, true
+}
+
+// BestMatch returns the most specific selector matching this platform,
+// or an empty string if none of them match. Ties are broken alphabetically
+// so the result does not depend on map iteration order.
+func (p *Platform) BestMatch(selectors []string) string {
+	sorted := append([]string(nil), selectors...)
+	sort.Strings(sorted)
+
+	best := ""
+	bestScore := 0
+	for _, sel := range sorted {
+		if score, ok := p.Match(sel); ok && score > bestScore {
+			best = sel
+			bestScore = score
+		}
+	}
+	return best
+}
+
+// matchArch compares against GOARCH, accepting common vendor aliases
+func (p *Platform) matchArch(arch string) bool {
+	aliases := map[string]string{
+		"x86_64":  "amd64",
+		"x64":     "amd64",
+		"aarch64": "arm64",
+	}
+	if alias, ok := aliases[arch]; ok {
+		arch = alias
+	}
+	return arch == p.Arch
+}
+
+// matchQualifier matches a distro ID, an ID_LIKE family, a libc or "wsl"
+func (p *Platform) matchQualifier(qualifier string) bool {
+	switch qualifier {
+	case "wsl":
+		return p.IsWSL
+	case "glibc", "musl":
+		return p.Libc == qualifier
+	}
+	if p.Distro == qualifier {
+		return true
+	}
+	for _, like := range p.DistroLike {
+		if like == qualifier {
+			return true
+		}
+	}
+	return false
+}
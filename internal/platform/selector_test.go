@@ -0,0 +1,119 @@
+package platform
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	debianArm := &Platform{OS: "linux", Arch: "arm64", Distro: "ubuntu", DistroLike: []string{"debian"}, Libc: "glibc"}
+	alpine := &Platform{OS: "linux", Arch: "amd64", Distro: "alpine", Libc: "musl"}
+	wsl := &Platform{OS: "linux", Arch: "amd64", Distro: "ubuntu", DistroLike: []string{"debian"}, Libc: "glibc", IsWSL: true}
+	mac := &Platform{OS: "darwin", Arch: "arm64"}
+
+	tests := []struct {
+		name     string
+		platform *Platform
+		selector string
+		want     bool
+	}{
+		{name: "OS only", platform: mac, selector: "darwin", want: true},
+		{name: "Wrong OS", platform: mac, selector: "linux", want: false},
+		{name: "OS and arch", platform: debianArm, selector: "linux/arm64", want: true},
+		{name: "Wrong arch", platform: debianArm, selector: "linux/amd64", want: false},
+		{name: "Arch alias", platform: debianArm, selector: "linux/aarch64", want: true},
+		{name: "Distro ID", platform: debianArm, selector: "linux:ubuntu", want: true},
+		{name: "Distro family", platform: debianArm, selector: "linux:debian", want: true},
+		{name: "Other distro", platform: debianArm, selector: "linux:fedora", want: false},
+		{name: "musl", platform: alpine, selector: "linux:musl", want: true},
+		{name: "musl on glibc", platform: debianArm, selector: "linux:musl", want: false},
+		{name: "Arch and libc", platform: alpine, selector: "linux/amd64:musl", want: true},
+		{name: "WSL", platform: wsl, selector: "wsl", want: true},
+		{name: "WSL qualifier", platform: wsl, selector: "linux:wsl", want: true},
+		{name: "WSL on native Linux", platform: debianArm, selector: "wsl", want: false},
+		{name: "Case insensitive", platform: mac, selector: "Darwin/ARM64", want: true},
+		{name: "Empty selector", platform: mac, selector: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, got := tt.platform.Match(tt.selector)
+			if got != tt.want {
+				t.Errorf("Match(%q) = %v, want %v", tt.selector, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBestMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		platform  *Platform
+		selectors []string
+		want      string
+	}{
+		{
+			name:      "Arch beats OS",
+			platform:  &Platform{OS: "linux", Arch: "arm64"},
+			selectors: []string{"linux", "linux/arm64", "darwin"},
+			want:      "linux/arm64",
+		},
+		{
+			name:      "Falls back to OS",
+			platform:  &Platform{OS: "linux", Arch: "amd64"},
+			selectors: []string{"linux", "linux/arm64"},
+			want:      "linux",
+		},
+		{
+			name:      "Arch beats distro",
+			platform:  &Platform{OS: "linux", Arch: "arm64", Distro: "debian"},
+			selectors: []string{"linux:debian", "linux/arm64"},
+			want:      "linux/arm64",
+		},
+		{
+			name:      "Arch and distro beat arch",
+			platform:  &Platform{OS: "linux", Arch: "arm64", Distro: "debian"},
+			selectors: []string{"linux/arm64:debian", "linux/arm64"},
+			want:      "linux/arm64:debian",
+		},
+		{
+			name:      "WSL beats linux",
+			platform:  &Platform{OS: "linux", Arch: "amd64", IsWSL: true},
+			selectors: []string{"linux", "wsl"},
+			want:      "wsl",
+		},
+		{
+			name:      "Tie is deterministic",
+			platform:  &Platform{OS: "linux", Arch: "amd64", Distro: "alpine", Libc: "musl"},
+			selectors: []string{"linux:musl", "linux:alpine"},
+			want:      "linux:alpine",
+		},
+		{
+			name:      "No match",
+			platform:  &Platform{OS: "windows", Arch: "amd64"},
+			selectors: []string{"linux", "darwin"},
+			want:      "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.platform.BestMatch(tt.selectors); got != tt.want {
+				t.Errorf("BestMatch(%v) = %q, want %q", tt.selectors, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseOSRelease(t *testing.T) {
+	data := `# comment
+NAME="Ubuntu"
+VERSION_ID="22.04"
+ID=ubuntu
+ID_LIKE=debian
+`
+	got := parseOSRelease(data)
+	want := map[string]string{"NAME": "Ubuntu", "VERSION_ID": "22.04", "ID": "ubuntu", "ID_LIKE": "debian"}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("parseOSRelease()[%q] = %q, want %q", k, got[k], v)
+		}
+	}
+}
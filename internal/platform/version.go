@@ -0,0 +1,92 @@
+package platform
+
+import (
+	"strconv"
+	"strings"
+)
+
+// CompareVersions compares two dotted version strings numerically.
+// It returns -1 if a < b, 0 if a == b and 1 if a > b. A leading "v" and
+// any non-numeric suffix ("3.12.1rc1", "20.1.0-beta") are ignored, and
+// missing components count as zero, so "18" == "18.0.0".
+func CompareVersions(a, b string) int {
+	pa := versionParts(a)
+	pb := versionParts(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x < y {
+			return -1
+		}
+		if x > y {
+			return 1
+		}
+	}
+	return 0
+}
+
+// SatisfiesVersion reports whether version meets a constraint such as
+// "18", ">=3.9" or ">=3.9,<3.13". A bare version means ">=".
+func SatisfiesVersion(version, constraint string) bool {
+	if version == "" {
+		return false
+	}
+	for _, c := range strings.Split(constraint, ",") {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			continue
+		}
+		op := ">="
+		for _, candidate := range []string{">=", "<=", "==", ">", "<", "="} {
+			if strings.HasPrefix(c, candidate) {
+				op = candidate
+				c = strings.TrimSpace(strings.TrimPrefix(c, candidate))
+				break
+			}
+		}
+		cmp := CompareVersions(version, c)
+		var ok bool
+		switch op {
+		case ">=":
+			ok = cmp >= 0
+		case "<=":
+			ok = cmp <= 0
+		case ">":
+			ok = cmp > 0
+		case "<":
+			ok = cmp < 0
+		default:
+			ok = cmp == 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func versionParts(v string) []int {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	var parts []int
+	for _, field := range strings.Split(v, ".") {
+		end := 0
+		for end < len(field) && field[end] >= '0' && field[end] <= '9' {
+			end++
+		}
+		if end == 0 {
+			break
+		}
+		n, _ := strconv.Atoi(field[:end])
+		parts = append(parts, n)
+		if end < len(field) {
+			// Pre-release or build suffix, stop here
+			break
+		}
+	}
+	return parts
+}
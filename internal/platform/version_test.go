@@ -0,0 +1,48 @@
+package platform
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "18.0.0", b: "18", want: 0},
+		{a: "v20.11.0", b: "18", want: 1},
+		{a: "16.20.2", b: "18", want: -1},
+		{a: "3.10", b: "3.9", want: 1},
+		{a: "3.12.1rc1", b: "3.12.1", want: 0},
+		{a: "2.24.5-desktop.1", b: "2", want: 1},
+		{a: "", b: "1", want: -1},
+	}
+
+	for _, tt := range tests {
+		if got := CompareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSatisfiesVersion(t *testing.T) {
+	tests := []struct {
+		version    string
+		constraint string
+		want       bool
+	}{
+		{version: "20.11.0", constraint: "18", want: true},
+		{version: "16.20.2", constraint: ">=18", want: false},
+		{version: "3.9.0", constraint: ">=3.9", want: true},
+		{version: "3.12.4", constraint: ">=3.9,<3.13", want: true},
+		{version: "3.13.0", constraint: ">=3.9,<3.13", want: false},
+		{version: "1.29.2", constraint: ">=2", want: false},
+		{version: "3.11.7", constraint: "=3.11", want: false},
+		{version: "3.11", constraint: "==3.11", want: true},
+		{version: "", constraint: ">=18", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := SatisfiesVersion(tt.version, tt.constraint); got != tt.want {
+			t.Errorf("SatisfiesVersion(%q, %q) = %v, want %v", tt.version, tt.constraint, got, tt.want)
+		}
+	}
+}
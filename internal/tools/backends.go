@@ -0,0 +1,238 @@
+package tools
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/getoai/getoai-cli/internal/installer"
+	"github.com/getoai/getoai-cli/internal/platform"
+)
+
+// backendRef matches a {tool} placeholder in a BackendEnv template
+var backendRef = regexp.MustCompile(`\{([a-z0-9-]+)\}`)
+
+// backendRefs returns the tools a BackendEnv template refers to
+func backendRefs(template string) []string {
+	var names []string
+	for _, m := range backendRef.FindAllStringSubmatch(template, -1) {
+		names = append(names, m[1])
+	}
+	return names
+}
+
+// expandBackendEnv fills in the BackendEnv templates with the base URLs of
+// the backends in urls. A variable referring to a backend that isn't in
+// urls is left out.
+func expandBackendEnv(templates map[string]string, urls map[string]string) map[string]string {
+	env := make(map[string]string)
+	for key, template := range templates {
+		complete := true
+		value := backendRef.ReplaceAllStringFunc(template, func(ref string) string {
+			url, ok := urls[ref[1:len(ref)-1]]
+			if !ok {
+				complete = false
+			}
+			return url
+		})
+		if complete {
+			env[key] = value
+		}
+	}
+	return env
+}
+
+// BackendURL returns the base URL a container on the getoai network reaches
+// the tool's API on, or "" if it isn't installed as a service. Native
+// servers and compose apps are reached on the host through
+// host.docker.internal, in which case viaHost is set.
+func (t *Tool) BackendURL() (url string, viaHost bool) {
+	switch t.ServiceKind() {
+	case ServiceContainer:
+		var ports []string
+		if spec := t.savedContainer(); spec != nil {
+			ports = spec.Ports
+		} else if config, ok := t.InstallMethods[installer.MethodDocker]; ok {
+			ports = config.DockerPorts
+		}
+		if len(ports) == 0 {
+			return "", false
+		}
+		port := strings.TrimSuffix(installer.ContainerPort(ports[0]), "/tcp")
+		return fmt.Sprintf("http://%s:%s", t.ContainerName(), port), false
+	case ServiceNative, ServiceCompose:
+		if port := t.hostPort(0); port != 0 {
+			return fmt.Sprintf("http://host.docker.internal:%d", port), true
+		}
+	}
+	return "", false
+}
+
+// backendURLs returns the base URLs of the installed backends the
+// templates refer to, and the ones reached through the host
+func backendURLs(templates map[string]string) (urls map[string]string, viaHost []string) {
+	urls = make(map[string]string)
+	for _, template := range templates {
+		for _, name := range backendRefs(template) {
+			if _, done := urls[name]; done {
+				continue
+			}
+			backend, ok := Get(name)
+			if !ok {
+				continue
+			}
+			if url, onHost := backend.BackendURL(); url != "" {
+				urls[name] = url
+				if onHost {
+					viaHost = append(viaHost, name)
+				}
+			}
+		}
+	}
+	sort.Strings(viaHost)
+	return urls, viaHost
+}
+
+// wireBackends points the container at the installed backends: it joins
+// the getoai network and gets the BackendEnv variables, plus the extra
+// ones (e.g. from a stack), that aren't set yet, so values chosen with
+// --env win. It returns the added variables.
+func (t *Tool) wireBackends(spec *installer.ContainerSpec, extra map[string]string) []string {
+	spec.Network = installer.NetworkName
+
+	templates := make(map[string]string)
+	for key, template := range t.BackendEnv {
+		templates[key] = template
+	}
+	for key, template := range extra {
+		templates[key] = template
+	}
+	urls, viaHost := backendURLs(templates)
+	unreachable := dropLoopbackBackends(urls, viaHost)
+	viaHost = slices.DeleteFunc(viaHost, func(name string) bool { return slices.Contains(unreachable, name) })
+	env := expandBackendEnv(templates, urls)
+	var added []string
+	for key, value := range env {
+		if _, ok := spec.Env[key]; ok {
+			continue
+		}
+		if spec.Env == nil {
+			spec.Env = make(map[string]string)
+		}
+		spec.Env[key] = value
+		added = append(added, key)
+	}
+	sort.Strings(added)
+
+	if len(viaHost) > 0 && !slices.Contains(spec.Hosts, installer.HostGateway) {
+		spec.Hosts = append(spec.Hosts, installer.HostGateway)
+	}
+	for _, key := range added {
+		fmt.Printf("Setting %s=%s\n", key, spec.Env[key])
+	}
+	for _, name := range unreachable {
+		backend, _ := Get(name)
+		how := "make it listen on all interfaces"
+		if backend.Service != nil && backend.Service.HostHint != "" {
+			how = fmt.Sprintf("start it with %s", backend.Service.HostHint)
+		}
+		fmt.Printf("\033[33m!\033[0m Not connecting %s to %s: it only listens on 127.0.0.1, which containers can't reach. To use it, %s and reinstall %s.\n", t.Name, name, how, t.Name)
+	}
+	// Docker Desktop forwards host.docker.internal to the host's loopback,
+	// on Linux it is the bridge address
+	for _, name := range viaHost {
+		if platform.Detect().OS != "linux" {
+			break
+		}
+		if backend, _ := Get(name); backend.ServiceKind() == ServiceNative {
+			fmt.Printf("Note: %s runs on the host, it must listen on all interfaces (not only 127.0.0.1) for containers to reach it\n", name)
+		}
+	}
+	return added
+}
+
+// dropLoopbackBackends removes from urls the native servers in viaHost
+// that only listen on the host's loopback, which containers can't reach on
+// Linux, and returns their names. Docker Desktop forwards
+// host.docker.internal to the loopback, so nothing is dropped elsewhere.
+func dropLoopbackBackends(urls map[string]string, viaHost []string) []string {
+	if platform.Detect().OS != "linux" {
+		return nil
+	}
+	var dropped []string
+	gateway := ""
+	for _, name := range viaHost {
+		backend, _ := Get(name)
+		if backend.ServiceKind() != ServiceNative {
+			continue
+		}
+		if gateway == "" {
+			if gateway = installer.BridgeGateway(); gateway == "" {
+				return nil
+			}
+		}
+		if installer.LoopbackOnly(backend.hostPort(0), gateway) {
+			delete(urls, name)
+			dropped = append(dropped, name)
+		}
+	}
+	return dropped
+}
+
+// Consumers returns the installed container tools whose BackendEnv refers
+// to the tool
+func (t *Tool) Consumers() []*Tool {
+	var consumers []*Tool
+	for _, tool := range List() {
+		if tool.ContainerName() == "" {
+			continue
+		}
+		for _, template := range tool.BackendEnv {
+			if slices.Contains(backendRefs(template), t.Name) && tool.ServiceKind() == ServiceContainer {
+				consumers = append(consumers, tool)
+				break
+			}
+		}
+	}
+	return consumers
+}
+
+// ConnectBackends recreates the tool's container with the variables of
+// backends installed since, and the extra ones, see wireBackends. When
+// confirm is set, it is asked first. The old container is kept until the
+// new one runs. It reports whether the container was recreated.
+func (t *Tool) ConnectBackends(extra map[string]string, confirm func() bool) (bool, error) {
+	r := t.receipt()
+	var spec *installer.ContainerSpec
+	if r != nil && r.Container != nil {
+		spec = r.Container
+	} else {
+		inspected, err := installer.InspectContainer(t.ContainerName())
+		if err != nil {
+			return false, err
+		}
+		spec = inspected
+	}
+
+	network, hosts := spec.Network, len(spec.Hosts)
+	if added := t.wireBackends(spec, extra); len(added) == 0 && network == spec.Network && hosts == len(spec.Hosts) {
+		return false, nil
+	}
+	if confirm != nil && !confirm() {
+		return false, nil
+	}
+	if err := installer.NewDockerInstaller().RecreateContainer(*spec); err != nil {
+		return false, err
+	}
+	if r == nil {
+		r = &Receipt{Tool: t.Name, Method: installer.MethodDocker, InstalledAt: time.Now().UTC()}
+	}
+	r.Container = spec
+	if err := r.Save(); err != nil {
+		fmt.Printf("Warning: failed to save install receipt: %v\n", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,76 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/getoai/getoai-cli/internal/installer"
+)
+
+func TestExpandBackendEnv(t *testing.T) {
+	templates := map[string]string{
+		"OLLAMA_BASE_URL":     "{ollama}",
+		"OPENAI_API_BASE_URL": "{localai}/v1",
+		"BOTH":                "{ollama},{localai}",
+	}
+	tests := []struct {
+		name string
+		urls map[string]string
+		want map[string]string
+	}{
+		{
+			name: "no backends",
+			urls: nil,
+			want: map[string]string{},
+		},
+		{
+			name: "native ollama",
+			urls: map[string]string{"ollama": "http://host.docker.internal:11434"},
+			want: map[string]string{"OLLAMA_BASE_URL": "http://host.docker.internal:11434"},
+		},
+		{
+			name: "both",
+			urls: map[string]string{"ollama": "http://ollama:11434", "localai": "http://localai:8080"},
+			want: map[string]string{
+				"OLLAMA_BASE_URL":     "http://ollama:11434",
+				"OPENAI_API_BASE_URL": "http://localai:8080/v1",
+				"BOTH":                "http://ollama:11434,http://localai:8080",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expandBackendEnv(templates, tt.urls); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandBackendEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackendEnvIsValid(t *testing.T) {
+	for _, tool := range List() {
+		if len(tool.BackendEnv) == 0 {
+			continue
+		}
+		if tool.ContainerName() == "" {
+			t.Errorf("%s: BackendEnv set on a tool that doesn't run as a container", tool.Name)
+		}
+		for key, template := range tool.BackendEnv {
+			refs := backendRefs(template)
+			if !installer.ValidEnvKey(key) || len(refs) == 0 {
+				t.Errorf("%s: invalid backend variable %s=%q", tool.Name, key, template)
+			}
+			for _, name := range refs {
+				backend, ok := Get(name)
+				if !ok {
+					t.Errorf("%s: %s refers to unknown tool %s", tool.Name, key, name)
+					continue
+				}
+				if backend.ContainerName() == "" && (backend.Service == nil || backend.Service.Port == 0) {
+					t.Errorf("%s: backend %s has neither a container nor a server port", tool.Name, name)
+				}
+			}
+		}
+	}
+}
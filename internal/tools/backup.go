@@ -0,0 +1,197 @@
+package tools
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/getoai/getoai-cli/internal/installer"
+)
+
+// BackupManifest describes the data of the installed service that a backup
+// holds: the container's volumes and host directories, or a compose app's
+// directory and project volumes, and the images it runs
+func (t *Tool) BackupManifest() (*installer.BackupManifest, error) {
+	m := &installer.BackupManifest{Tool: t.Name, Kind: string(t.ServiceKind()), CreatedAt: time.Now().UTC()}
+
+	switch t.ServiceKind() {
+	case ServiceContainer:
+		spec, err := t.currentContainer()
+		if err != nil {
+			return nil, err
+		}
+		m.Version = installer.ImageVersion(spec.Image)
+		m.Images = []installer.BackupImage{{Image: spec.Image, Digest: installer.ImageDigest(spec.Image)}}
+		for _, v := range spec.Volumes {
+			source := installer.VolumeSource(v)
+			if source == "" {
+				continue
+			}
+			if !installer.IsNamedVolume(source) {
+				if abs, err := filepath.Abs(source); err == nil {
+					source = abs
+				}
+			}
+			m.Mounts = append(m.Mounts, installer.BackupMount{Source: source, Target: installer.VolumeTarget(v)})
+		}
+
+	case ServiceCompose:
+		installDir := t.GetComposeInstallDir()
+		composeFile := installer.FindComposeFile(installDir)
+		if src := installer.ReadComposeSource(installDir); src != nil {
+			m.Version = src.RefName()
+		}
+		for _, image := range installer.ComposeImages(composeFile) {
+			m.Images = append(m.Images, installer.BackupImage{Image: image, Digest: installer.ImageDigest(image)})
+		}
+		// The directory holds the compose file, .env and bind-mounted data
+		m.Mounts = append(m.Mounts, installer.BackupMount{Source: installDir})
+		_, volumes, err := installer.ComposeProject(composeFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range sortedMapKeys(volumes) {
+			m.Mounts = append(m.Mounts, installer.BackupMount{Source: volumes[key], Volume: key})
+		}
+
+	case ServiceNative:
+		return nil, fmt.Errorf("%s runs natively, back up its data directory directly", t.Name)
+	default:
+		return nil, t.notAService()
+	}
+
+	if len(m.Mounts) == 0 {
+		return nil, fmt.Errorf("%s has no volumes to back up", t.Name)
+	}
+	return m, nil
+}
+
+// currentContainer returns the settings of the tool's container, as docker
+// reports them if it exists, so relative host paths are resolved
+func (t *Tool) currentContainer() (*installer.ContainerSpec, error) {
+	if spec, err := installer.InspectContainer(t.ContainerName()); err == nil {
+		return spec, nil
+	}
+	if spec := t.savedContainer(); spec != nil {
+		return spec, nil
+	}
+	return nil, fmt.Errorf("container %s not found", t.ContainerName())
+}
+
+// Backup writes the service's data to path. The service is stopped while
+// its data is archived, and started again if it was running.
+func (t *Tool) Backup(path string, m *installer.BackupManifest) error {
+	return t.whileStopped(func() error {
+		return installer.WriteBackup(path, m)
+	})
+}
+
+// CheckBackup verifies that a backup can be restored into the installed
+// tool. Differences that may need a data migration, such as another image
+// or version, are returned as warnings.
+func (t *Tool) CheckBackup(m *installer.BackupManifest) ([]string, error) {
+	if m.Tool != t.Name {
+		return nil, fmt.Errorf("the backup is of %s, not %s", m.Tool, t.Name)
+	}
+	kind := t.ServiceKind()
+	if kind == ServiceNone {
+		return nil, fmt.Errorf("%s is not installed, install it before restoring", t.Name)
+	}
+	if string(kind) != m.Kind {
+		return nil, fmt.Errorf("the backup is of a %s install, %s is installed as a %s", m.Kind, t.Name, kind)
+	}
+
+	current, err := t.BackupManifest()
+	if err != nil {
+		return nil, err
+	}
+	var warnings []string
+	if m.Version != "" && current.Version != "" && m.Version != current.Version {
+		warnings = append(warnings, fmt.Sprintf("the backup was taken from version %s, %s is now at %s", m.Version, t.Name, current.Version))
+	}
+	digests := make(map[string]string)
+	for _, image := range current.Images {
+		digests[image.Image] = image.Digest
+	}
+	for _, image := range m.Images {
+		digest, ok := digests[image.Image]
+		switch {
+		case !ok:
+			warnings = append(warnings, fmt.Sprintf("the backup was taken with image %s, which is no longer used", image.Image))
+		case image.Digest != "" && digest != "" && image.Digest != digest:
+			warnings = append(warnings, fmt.Sprintf("image %s has changed since the backup", image.Image))
+		}
+	}
+	if _, skipped := t.restoreTargets(m, current); len(skipped) > 0 {
+		for _, mount := range skipped {
+			warnings = append(warnings, fmt.Sprintf("%s is no longer mounted and will not be restored", mount))
+		}
+	}
+	return warnings, nil
+}
+
+// restoreTargets maps the mounts of a backup to the current volumes and
+// directories of the service: container mounts by their path in the
+// container, compose volumes by name. It also returns the mounts that
+// have no counterpart.
+func (t *Tool) restoreTargets(backup, current *installer.BackupManifest) (map[string]string, []string) {
+	dest := make(map[string]string)
+	var skipped []string
+	for _, mount := range backup.Mounts {
+		var target string
+		for _, cur := range current.Mounts {
+			// A mount with neither is the compose app's directory
+			if mount.Target == cur.Target && mount.Volume == cur.Volume {
+				target = cur.Source
+				break
+			}
+		}
+		if target == "" {
+			skipped = append(skipped, mount.Source)
+			continue
+		}
+		dest[mount.Entry] = target
+	}
+	return dest, skipped
+}
+
+// Restore replaces the service's data with the backup. The service is
+// stopped while the data is replaced, and started again if it was running.
+func (t *Tool) Restore(path string, m *installer.BackupManifest) error {
+	current, err := t.BackupManifest()
+	if err != nil {
+		return err
+	}
+	dest, _ := t.restoreTargets(m, current)
+	return t.whileStopped(func() error {
+		return installer.RestoreBackup(path, dest)
+	})
+}
+
+// whileStopped runs fn with the service stopped, then starts it again if
+// it was running before
+func (t *Tool) whileStopped(fn func() error) error {
+	running := t.Status().Running
+	if running {
+		if err := t.Stop(); err != nil {
+			return fmt.Errorf("failed to stop %s: %w", t.Name, err)
+		}
+	}
+	err := fn()
+	if running {
+		if startErr := t.Start(); startErr != nil && err == nil {
+			err = fmt.Errorf("failed to start %s again: %w", t.Name, startErr)
+		}
+	}
+	return err
+}
+
+func sortedMapKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,40 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/getoai/getoai-cli/internal/installer"
+)
+
+func TestRestoreTargets(t *testing.T) {
+	tool := &Tool{Name: "dify"}
+	backup := &installer.BackupManifest{Mounts: []installer.BackupMount{
+		{Entry: "mounts/0.tar", Source: "/old/home/.getoai/tools/dify"},
+		{Entry: "mounts/1.tar", Source: "docker_db", Volume: "db"},
+		{Entry: "mounts/2.tar", Source: "docker_cache", Volume: "cache"},
+		{Entry: "mounts/3.tar", Source: "models", Target: "/models"},
+	}}
+	current := &installer.BackupManifest{Mounts: []installer.BackupMount{
+		{Source: "/home/me/.getoai/tools/dify"},
+		{Source: "dify_db", Volume: "db"},
+		{Source: "/srv/models", Target: "/models"},
+	}}
+
+	dest, skipped := tool.restoreTargets(backup, current)
+	want := map[string]string{
+		"mounts/0.tar": "/home/me/.getoai/tools/dify",
+		"mounts/1.tar": "dify_db",
+		"mounts/3.tar": "/srv/models",
+	}
+	if len(dest) != len(want) {
+		t.Errorf("dest = %v, want %v", dest, want)
+	}
+	for entry, target := range want {
+		if dest[entry] != target {
+			t.Errorf("dest[%q] = %q, want %q", entry, dest[entry], target)
+		}
+	}
+	if len(skipped) != 1 || skipped[0] != "docker_cache" {
+		t.Errorf("skipped = %v, want [docker_cache]", skipped)
+	}
+}
@@ -0,0 +1,365 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getoai/getoai-cli/internal/installer"
+	"github.com/getoai/getoai-cli/internal/platform"
+)
+
+// PlanStep is one tool in an installation plan
+type PlanStep struct {
+	Tool       *Tool
+	Requested  bool   // explicitly requested by the user
+	RequiredBy string // tool that pulled this one in, if not requested
+	Reason     string // "requires" or "recommends"
+}
+
+// Conflict is a conflicting pair of tools found in an installation plan
+type Conflict struct {
+	Tool      string // tool about to be installed
+	With      string // conflicting tool
+	Reason    string // the tool name or capability they clash on
+	Installed bool   // With is already installed (otherwise it is in the plan)
+}
+
+// graph gives the resolver access to tools, capabilities and install state
+// so it can be tested without the global registry
+type graph struct {
+	tools     map[string]*Tool
+	installed func(*Tool) bool
+	method    installer.InstallMethod // install method of the requested tools, "" if chosen later
+}
+
+func registryGraph() *graph {
+	return &graph{
+		tools:     registry,
+		installed: func(t *Tool) bool { return t.IsInstalled() },
+	}
+}
+
+// providers returns the tools that provide a capability, sorted by name
+func (g *graph) providers(capability string) []*Tool {
+	var providers []*Tool
+	for _, t := range g.tools {
+		for _, p := range t.Provides {
+			if p == capability {
+				providers = append(providers, t)
+				break
+			}
+		}
+	}
+	sort.Slice(providers, func(i, j int) bool {
+		return providers[i].Name < providers[j].Name
+	})
+	return providers
+}
+
+// ResolveInstallPlan resolves the Requires relations of the given tools,
+// and their Recommends relations if withRecommended is set, and returns the
+// tools in installation order: every tool comes after its dependencies.
+// A requirement naming a capability (e.g. "docker-engine") is satisfied by
+// any tool that provides it. The requirements of method are added for the
+// requested tools; other tools only get those all their methods share. It
+// fails on unknown tools and dependency cycles.
+func ResolveInstallPlan(names []string, method installer.InstallMethod, withRecommended bool) ([]PlanStep, error) {
+	g := registryGraph()
+	g.method = method
+	return g.resolve(names, withRecommended)
+}
+
+// requires returns the tools and capabilities t needs installed first when
+// installed with method: its Requires and those of the method. While the
+// method isn't chosen yet, a method requirement only counts when every
+// install method declares it.
+func (t *Tool) requires(method installer.InstallMethod) []string {
+	p := platform.Detect()
+	deps := append([]string(nil), t.Requires...)
+	if method != "" {
+		config, _ := t.configFor(p, method)
+		return append(deps, config.Requires...)
+	}
+
+	count := make(map[string]int)
+	for m := range t.InstallMethods {
+		config, _ := t.configFor(p, m)
+		for _, dep := range config.Requires {
+			count[dep]++
+		}
+	}
+	for dep, n := range count {
+		if n == len(t.InstallMethods) {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// UnmetRequires returns the requirements of installing the tool with method
+// that no installed tool satisfies
+func (t *Tool) UnmetRequires(method installer.InstallMethod) []string {
+	g := registryGraph()
+	var unmet []string
+	for _, dep := range sortedCopy(t.requires(method)) {
+		satisfied := false
+		if tool, ok := g.tools[dep]; ok {
+			satisfied = g.installed(tool)
+		}
+		for _, p := range g.providers(dep) {
+			satisfied = satisfied || g.installed(p)
+		}
+		if !satisfied {
+			unmet = append(unmet, dep)
+		}
+	}
+	return unmet
+}
+
+func (g *graph) resolve(names []string, withRecommended bool) ([]PlanStep, error) {
+	const (
+		visiting = iota + 1
+		done
+	)
+
+	state := make(map[string]int)
+	var order []PlanStep
+	var path []string
+
+	requested := make(map[string]bool)
+	for _, name := range names {
+		requested[name] = true
+	}
+
+	var visit func(name, parent, reason string) error
+	visit = func(name, parent, reason string) error {
+		switch state[name] {
+		case done:
+			return nil
+		case visiting:
+			// Report the cycle starting from the first occurrence of name
+			start := 0
+			for i, n := range path {
+				if n == name {
+					start = i
+					break
+				}
+			}
+			cycle := append(append([]string(nil), path[start:]...), name)
+			return fmt.Errorf("dependency cycle detected: %s", strings.Join(cycle, " -> "))
+		}
+
+		tool, ok := g.tools[name]
+		if !ok {
+			if parent == "" {
+				return fmt.Errorf("unknown tool: %s", name)
+			}
+			provider := g.chooseProvider(name, func(t *Tool) bool {
+				return state[t.Name] != 0 || requested[t.Name]
+			})
+			if provider == nil {
+				return fmt.Errorf("%s %s unknown tool or capability %q", parent, reason, name)
+			}
+			if state[provider.Name] == done || g.installed(provider) {
+				// Already satisfied by a planned or installed provider
+				return nil
+			}
+			return visit(provider.Name, parent, reason)
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+
+		var method installer.InstallMethod
+		if requested[name] {
+			method = g.method
+		}
+		for _, dep := range sortedCopy(tool.requires(method)) {
+			if err := visit(dep, name, "requires"); err != nil {
+				return err
+			}
+		}
+		if withRecommended {
+			for _, dep := range sortedCopy(tool.Recommends) {
+				// Recommendations are soft and may point back at each other
+				if state[dep] == visiting {
+					continue
+				}
+				if err := visit(dep, name, "recommends"); err != nil {
+					return err
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[name] = done
+
+		step := PlanStep{Tool: tool, Requested: requested[name]}
+		if !step.Requested {
+			step.RequiredBy = parent
+			step.Reason = reason
+		}
+		order = append(order, step)
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name, "", ""); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// chooseProvider picks the tool that satisfies a capability: one already
+// planned, then one already installed, then the first provider by name
+func (g *graph) chooseProvider(capability string, planned func(*Tool) bool) *Tool {
+	providers := g.providers(capability)
+	if len(providers) == 0 {
+		return nil
+	}
+	for _, p := range providers {
+		if planned(p) {
+			return p
+		}
+	}
+	for _, p := range providers {
+		if g.installed(p) {
+			return p
+		}
+	}
+	return providers[0]
+}
+
+// FindConflicts returns the conflicts between the tools of a plan that are
+// not installed yet and the other planned or already installed tools
+func FindConflicts(plan []PlanStep) []Conflict {
+	return registryGraph().conflicts(plan)
+}
+
+func (g *graph) conflicts(plan []PlanStep) []Conflict {
+	planned := make(map[string]bool)
+	for _, step := range plan {
+		planned[step.Tool.Name] = true
+	}
+
+	var conflicts []Conflict
+	seen := make(map[string]bool)
+	for _, step := range plan {
+		if g.installed(step.Tool) {
+			continue
+		}
+		for _, other := range g.conflictCandidates(step.Tool) {
+			// Report each pair only once
+			if seen[step.Tool.Name+"|"+other.Name] {
+				continue
+			}
+			seen[step.Tool.Name+"|"+other.Name] = true
+			seen[other.Name+"|"+step.Tool.Name] = true
+
+			reason, _ := conflictReason(step.Tool, other)
+			if planned[other.Name] {
+				conflicts = append(conflicts, Conflict{Tool: step.Tool.Name, With: other.Name, Reason: reason})
+			} else if g.installed(other) {
+				conflicts = append(conflicts, Conflict{Tool: step.Tool.Name, With: other.Name, Reason: reason, Installed: true})
+			}
+		}
+	}
+	return conflicts
+}
+
+// conflictCandidates returns the tools declared as conflicting with t, in
+// either direction, without checking the install state of the registry
+func (g *graph) conflictCandidates(t *Tool) []*Tool {
+	var candidates []*Tool
+	for _, other := range g.tools {
+		if other.Name == t.Name {
+			continue
+		}
+		if _, ok := conflictReason(t, other); ok {
+			candidates = append(candidates, other)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Name < candidates[j].Name
+	})
+	return candidates
+}
+
+// conflictReason reports whether a and b conflict and on which tool name or
+// capability. Conflicts are symmetric: either side may declare them.
+func conflictReason(a, b *Tool) (string, bool) {
+	if reason, ok := declaresConflict(a, b); ok {
+		return reason, true
+	}
+	return declaresConflict(b, a)
+}
+
+func declaresConflict(a, b *Tool) (string, bool) {
+	for _, c := range a.Conflicts {
+		if c == b.Name {
+			return c, true
+		}
+		for _, p := range b.Provides {
+			if c == p {
+				return c, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Dependents returns the installed tools that require the named tool,
+// directly or through a capability no other installed tool provides
+func Dependents(name string) []*Tool {
+	return registryGraph().dependents(name)
+}
+
+func (g *graph) dependents(name string) []*Tool {
+	target, ok := g.tools[name]
+	if !ok {
+		return nil
+	}
+
+	needed := map[string]bool{name: true}
+	for _, capability := range target.Provides {
+		alternative := false
+		for _, p := range g.providers(capability) {
+			if p.Name != name && g.installed(p) {
+				alternative = true
+				break
+			}
+		}
+		if !alternative {
+			needed[capability] = true
+		}
+	}
+
+	var dependents []*Tool
+	for _, tool := range g.tools {
+		if !g.installed(tool) {
+			continue
+		}
+		var method installer.InstallMethod
+		if r := tool.receipt(); r != nil {
+			method = r.Method
+		}
+		for _, dep := range tool.requires(method) {
+			if needed[dep] {
+				dependents = append(dependents, tool)
+				break
+			}
+		}
+	}
+	sort.Slice(dependents, func(i, j int) bool {
+		return dependents[i].Name < dependents[j].Name
+	})
+	return dependents
+}
+
+func sortedCopy(names []string) []string {
+	out := append([]string(nil), names...)
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,281 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getoai/getoai-cli/internal/installer"
+)
+
+// testGraph builds a graph from tools; installed lists the installed tool names
+func testGraph(installed []string, tools ...*Tool) *graph {
+	g := &graph{tools: make(map[string]*Tool)}
+	for _, t := range tools {
+		g.tools[t.Name] = t
+	}
+	isInstalled := make(map[string]bool)
+	for _, name := range installed {
+		isInstalled[name] = true
+	}
+	g.installed = func(t *Tool) bool { return isInstalled[t.Name] }
+	return g
+}
+
+func planNames(plan []PlanStep) []string {
+	names := make([]string, len(plan))
+	for i, step := range plan {
+		names[i] = step.Tool.Name
+	}
+	return names
+}
+
+func TestResolvePlanOrdersDependenciesFirst(t *testing.T) {
+	g := testGraph(nil,
+		&Tool{Name: "app", Requires: []string{"lib", "runtime"}},
+		&Tool{Name: "lib", Requires: []string{"runtime"}},
+		&Tool{Name: "runtime"},
+	)
+
+	plan, err := g.resolve([]string{"app"}, false)
+	if err != nil {
+		t.Fatalf("resolve() error = %v", err)
+	}
+
+	got := strings.Join(planNames(plan), ",")
+	if got != "runtime,lib,app" {
+		t.Errorf("resolve() order = %s, want runtime,lib,app", got)
+	}
+	if !plan[2].Requested || plan[0].Requested {
+		t.Error("only app should be marked as requested")
+	}
+	if plan[1].RequiredBy != "app" || plan[1].Reason != "requires" {
+		t.Errorf("lib required by %q (%s), want app (requires)", plan[1].RequiredBy, plan[1].Reason)
+	}
+}
+
+func TestResolvePlanRecommends(t *testing.T) {
+	g := testGraph(nil,
+		&Tool{Name: "ui", Recommends: []string{"backend"}},
+		&Tool{Name: "backend", Recommends: []string{"ui"}},
+	)
+
+	plan, err := g.resolve([]string{"ui"}, false)
+	if err != nil || len(plan) != 1 {
+		t.Fatalf("without recommended: plan = %v, err = %v", planNames(plan), err)
+	}
+
+	// Recommendations pointing at each other are not a cycle
+	plan, err = g.resolve([]string{"ui"}, true)
+	if err != nil {
+		t.Fatalf("with recommended: error = %v", err)
+	}
+	if got := strings.Join(planNames(plan), ","); got != "backend,ui" {
+		t.Errorf("with recommended: order = %s, want backend,ui", got)
+	}
+}
+
+func TestResolvePlanDetectsCycles(t *testing.T) {
+	g := testGraph(nil,
+		&Tool{Name: "a", Requires: []string{"b"}},
+		&Tool{Name: "b", Requires: []string{"c"}},
+		&Tool{Name: "c", Requires: []string{"a"}},
+	)
+
+	_, err := g.resolve([]string{"a"}, false)
+	if err == nil {
+		t.Fatal("expected cycle error")
+	}
+	if !strings.Contains(err.Error(), "a -> b -> c -> a") {
+		t.Errorf("error = %q, want cycle path a -> b -> c -> a", err)
+	}
+}
+
+func TestResolvePlanUnknownDependency(t *testing.T) {
+	g := testGraph(nil, &Tool{Name: "a", Requires: []string{"missing"}})
+
+	if _, err := g.resolve([]string{"a"}, false); err == nil {
+		t.Error("expected error for unknown dependency")
+	}
+}
+
+func TestRegistryDependencyGraph(t *testing.T) {
+	var names []string
+	for _, tool := range List() {
+		names = append(names, tool.Name)
+	}
+
+	// Every relation must point at a registered tool and the graph must be acyclic
+	if _, err := ResolveInstallPlan(names, "", true); err != nil {
+		t.Errorf("registry dependency graph is invalid: %v", err)
+	}
+	for _, tool := range List() {
+		for method, config := range tool.InstallMethods {
+			if len(config.Requires) == 0 {
+				continue
+			}
+			if _, err := ResolveInstallPlan([]string{tool.Name}, method, false); err != nil {
+				t.Errorf("%s %s method: %v", tool.Name, method, err)
+			}
+		}
+	}
+}
+
+func TestResolvePlanMethodRequires(t *testing.T) {
+	g := testGraph(nil,
+		&Tool{Name: "cli", InstallMethods: map[installer.InstallMethod]InstallConfig{
+			installer.MethodGo:  {Requires: []string{"go"}},
+			installer.MethodPip: {},
+		}},
+		&Tool{Name: "app", InstallMethods: map[installer.InstallMethod]InstallConfig{
+			installer.MethodNpm: {Requires: []string{"node"}},
+		}},
+		&Tool{Name: "go"},
+		&Tool{Name: "node"},
+	)
+
+	tests := []struct {
+		name   string
+		method installer.InstallMethod
+		want   string
+	}{
+		{"cli", "", "cli"}, // the pip method doesn't need go
+		{"cli", installer.MethodGo, "go,cli"},
+		{"cli", installer.MethodPip, "cli"},
+		{"app", "", "node,app"}, // every method needs node
+	}
+	for _, tt := range tests {
+		g.method = tt.method
+		plan, err := g.resolve([]string{tt.name}, false)
+		if err != nil {
+			t.Fatalf("resolve(%s, %q) error = %v", tt.name, tt.method, err)
+		}
+		if got := strings.Join(planNames(plan), ","); got != tt.want {
+			t.Errorf("resolve(%s, %q) = %s, want %s", tt.name, tt.method, got, tt.want)
+		}
+	}
+}
+
+func TestResolvePlanCapabilities(t *testing.T) {
+	tools := []*Tool{
+		{Name: "compose", Requires: []string{"engine"}},
+		{Name: "docker", Provides: []string{"engine"}},
+		{Name: "orbstack", Provides: []string{"engine"}},
+	}
+
+	// Without an installed provider the first provider by name is planned
+	plan, err := testGraph(nil, tools...).resolve([]string{"compose"}, false)
+	if err != nil {
+		t.Fatalf("resolve() error = %v", err)
+	}
+	if got := strings.Join(planNames(plan), ","); got != "docker,compose" {
+		t.Errorf("plan = %s, want docker,compose", got)
+	}
+
+	// Any installed provider satisfies the capability
+	plan, err = testGraph([]string{"orbstack"}, tools...).resolve([]string{"compose"}, false)
+	if err != nil {
+		t.Fatalf("resolve() error = %v", err)
+	}
+	if got := strings.Join(planNames(plan), ","); got != "compose" {
+		t.Errorf("plan = %s, want compose", got)
+	}
+
+	// A requested provider is preferred over the default one
+	plan, err = testGraph(nil, tools...).resolve([]string{"orbstack", "compose"}, false)
+	if err != nil {
+		t.Fatalf("resolve() error = %v", err)
+	}
+	if got := strings.Join(planNames(plan), ","); got != "orbstack,compose" {
+		t.Errorf("plan = %s, want orbstack,compose", got)
+	}
+}
+
+func TestConflicts(t *testing.T) {
+	tools := []*Tool{
+		{Name: "docker", Provides: []string{"engine"}},
+		{Name: "podman-docker", Conflicts: []string{"engine"}},
+		{Name: "openai-cli", Conflicts: []string{"chatgpt-cli"}},
+		{Name: "chatgpt-cli"},
+	}
+
+	// Conflict with an installed tool through a capability
+	g := testGraph([]string{"docker"}, tools...)
+	plan, _ := g.resolve([]string{"podman-docker"}, false)
+	conflicts := g.conflicts(plan)
+	if len(conflicts) != 1 || conflicts[0].With != "docker" || !conflicts[0].Installed || conflicts[0].Reason != "engine" {
+		t.Errorf("conflicts = %+v, want podman-docker vs installed docker on engine", conflicts)
+	}
+
+	// Conflicts are symmetric and reported once within a plan
+	g = testGraph(nil, tools...)
+	plan, _ = g.resolve([]string{"chatgpt-cli", "openai-cli"}, false)
+	conflicts = g.conflicts(plan)
+	if len(conflicts) != 1 || conflicts[0].Installed {
+		t.Errorf("conflicts = %+v, want a single planned conflict", conflicts)
+	}
+
+	// No conflict when nothing conflicting is installed or planned
+	g = testGraph(nil, tools...)
+	plan, _ = g.resolve([]string{"podman-docker"}, false)
+	if conflicts = g.conflicts(plan); len(conflicts) != 0 {
+		t.Errorf("conflicts = %+v, want none", conflicts)
+	}
+}
+
+func TestRegistryRequirementsDontConflict(t *testing.T) {
+	g := registryGraph()
+	for _, tool := range List() {
+		for _, dep := range tool.Requires {
+			providers := g.providers(dep)
+			if required, ok := g.tools[dep]; ok {
+				providers = append(providers, required)
+			}
+			for _, p := range providers {
+				if reason, ok := conflictReason(tool, p); ok {
+					t.Errorf("%s requires %s but conflicts with its provider %s on %s", tool.Name, dep, p.Name, reason)
+				}
+			}
+		}
+	}
+}
+
+func TestRegistryConflicts(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		reason string
+	}{
+		{"docker", "orbstack", "orbstack"},
+		{"docker-compose", "docker", "compose-v2"},
+		{"orbstack", "docker-compose", "compose-v2"},
+		{"chatgpt-cli", "openai-cli", "chatgpt-cli"},
+	}
+	for _, tt := range tests {
+		a, okA := Get(tt.a)
+		b, okB := Get(tt.b)
+		if !okA || !okB {
+			t.Fatalf("%s or %s is not registered", tt.a, tt.b)
+		}
+		if reason, ok := conflictReason(a, b); !ok || reason != tt.reason {
+			t.Errorf("conflictReason(%s, %s) = %q, %v, want %q", tt.a, tt.b, reason, ok, tt.reason)
+		}
+	}
+}
+
+func TestDependentsThroughCapability(t *testing.T) {
+	tools := []*Tool{
+		{Name: "compose", Requires: []string{"engine"}},
+		{Name: "docker", Provides: []string{"engine"}},
+		{Name: "orbstack", Provides: []string{"engine"}},
+	}
+
+	g := testGraph([]string{"compose", "docker"}, tools...)
+	if got := g.dependents("docker"); len(got) != 1 || got[0].Name != "compose" {
+		t.Errorf("dependents(docker) = %v, want [compose]", got)
+	}
+
+	// Another installed provider keeps the capability available
+	g = testGraph([]string{"compose", "docker", "orbstack"}, tools...)
+	if got := g.dependents("docker"); len(got) != 0 {
+		t.Errorf("dependents(docker) = %v, want none", got)
+	}
+}
@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/getoai/getoai-cli/internal/installer"
+	"github.com/getoai/getoai-cli/internal/platform"
+)
+
+// EnvFile returns the .env file next to the compose file of an installed
+// compose app
+func (t *Tool) EnvFile() (string, error) {
+	installDir := t.GetComposeInstallDir()
+	if !t.IsDockerComposeInstall() || installDir == "" {
+		if t.ContainerName() != "" {
+			return "", fmt.Errorf("%s runs as a single container, change its env with 'getoai install %s --env KEY=VALUE'", t.Name, t.Name)
+		}
+		return "", fmt.Errorf("%s is not installed as a compose app", t.Name)
+	}
+	composeFile := installer.FindComposeFile(installDir)
+	if composeFile == "" {
+		return "", fmt.Errorf("no docker-compose file found in %s", installDir)
+	}
+	return filepath.Join(filepath.Dir(composeFile), ".env"), nil
+}
+
+// IsSecretEnv reports whether an .env key holds a secret: one generated at
+// install time, or one whose name says so
+func (t *Tool) IsSecretEnv(key string) bool {
+	if config, ok := t.configFor(platform.Detect(), installer.MethodDocker); ok {
+		if _, ok := config.ComposeSecrets[key]; ok {
+			return true
+		}
+		if _, ok := config.SecretRefs[key]; ok {
+			return true
+		}
+	}
+	return looksSecret(key)
+}
+
+// looksSecret guesses from a variable name whether it holds a credential
+func looksSecret(key string) bool {
+	key = strings.ToUpper(key)
+	for _, word := range []string{"SECRET", "PASSWORD", "PASSWD", "TOKEN", "PRIVATE"} {
+		if strings.Contains(key, word) {
+			return true
+		}
+	}
+	return strings.HasSuffix(key, "_KEY") || strings.HasSuffix(key, "_IV")
+}
+
+// ApplyEnv recreates the compose app's containers whose settings changed,
+// so they pick up edits to .env
+func (t *Tool) ApplyEnv() error {
+	if t.ServiceKind() != ServiceCompose {
+		return fmt.Errorf("%s is not installed as a compose app", t.Name)
+	}
+	return installer.NewDockerInstaller().ComposeUp(t.GetComposeInstallDir())
+}
@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/getoai/getoai-cli/internal/installer"
+)
+
+func TestLooksSecret(t *testing.T) {
+	tests := map[string]bool{
+		"SECRET_KEY":         true,
+		"DB_PASSWORD":        true,
+		"JWT_REFRESH_SECRET": true,
+		"CREDS_IV":           true,
+		"OPENAI_API_KEY":     true,
+		"github_token":       true,
+		"EXPOSE_NGINX_PORT":  false,
+		"KEYCLOAK_URL":       false,
+		"LOG_LEVEL":          false,
+	}
+	for key, want := range tests {
+		if got := looksSecret(key); got != want {
+			t.Errorf("looksSecret(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestComposeSecretsAreValid(t *testing.T) {
+	for _, tool := range List() {
+		for method, config := range tool.InstallMethods {
+			if len(config.ComposeSecrets) == 0 {
+				continue
+			}
+			if method != installer.MethodDocker || config.DockerCompose == "" {
+				t.Errorf("%s: ComposeSecrets set on a method without DockerCompose", tool.Name)
+			}
+			for key, size := range config.ComposeSecrets {
+				if !installer.ValidEnvKey(key) || size < 16 {
+					t.Errorf("%s: invalid secret %s (%d bytes)", tool.Name, key, size)
+				}
+			}
+			for key, template := range config.SecretRefs {
+				if !installer.ValidEnvKey(key) {
+					t.Errorf("%s: invalid secret reference %s", tool.Name, key)
+				}
+				for _, ref := range installer.SecretRefs(template) {
+					if _, ok := config.ComposeSecrets[ref]; !ok {
+						t.Errorf("%s: %s refers to %s, which is not in ComposeSecrets", tool.Name, key, ref)
+					}
+				}
+			}
+		}
+	}
+}
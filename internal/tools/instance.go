@@ -0,0 +1,152 @@
+package tools
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getoai/getoai-cli/internal/config"
+	"github.com/getoai/getoai-cli/internal/installer"
+)
+
+// instancePattern is what an instance may be called: it ends up in container,
+// volume and compose project names
+var instancePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+
+// SplitInstance splits "open-webui:staging" into the tool and instance
+// names; the instance is empty for the default one
+func SplitInstance(id string) (name, instance string) {
+	name, instance, _ = strings.Cut(id, ":")
+	return name, instance
+}
+
+// ValidInstance reports whether an instance name can be used
+func ValidInstance(instance string) error {
+	if !instancePattern.MatchString(instance) {
+		return fmt.Errorf("invalid instance name %q: use up to 32 lowercase letters, digits and dashes", instance)
+	}
+	return nil
+}
+
+// ID returns how the install is addressed on the command line: the tool
+// name, followed by ":<instance>" for a named instance
+func (t *Tool) ID() string {
+	if t.Instance == "" {
+		return t.Name
+	}
+	return t.Name + ":" + t.Instance
+}
+
+// instanceName returns name namespaced to the tool's instance, for
+// containers and named volumes
+func (t *Tool) instanceName(name string) string {
+	if t.Instance == "" {
+		return name
+	}
+	return name + "-" + t.Instance
+}
+
+// stateName returns the file and directory name of the install's state
+// (receipt, compose directory); ":" isn't allowed in Windows paths
+func stateName(id string) string {
+	return strings.Replace(id, ":", "@", 1)
+}
+
+// instanceSpec namespaces the registry defaults of a container for the
+// tool's instance: its name and named volumes
+func (t *Tool) instanceSpec(spec *installer.ContainerSpec) {
+	if t.Instance == "" {
+		return
+	}
+	spec.Name = t.instanceName(spec.Name)
+	for i, v := range spec.Volumes {
+		if source := installer.VolumeSource(v); installer.IsNamedVolume(source) {
+			spec.Volumes[i] = t.instanceName(source) + strings.TrimPrefix(v, source)
+		}
+	}
+}
+
+// composeProject returns the compose project name of the tool's instance,
+// or "" to keep the default one of the compose file
+func (t *Tool) composeProject() string {
+	if t.Instance == "" {
+		return ""
+	}
+	return t.Name + "-" + t.Instance
+}
+
+// siblingPorts returns the host ports recorded for the tool's other
+// installs, which its instance stays clear of even when they are stopped
+func (t *Tool) siblingPorts() []int {
+	var ports []int
+	for _, id := range installIDs(t.Name) {
+		if id == t.ID() {
+			continue
+		}
+		r, _ := LoadReceipt(id)
+		if r == nil || r.Container == nil {
+			continue
+		}
+		for _, mapping := range r.Container.Ports {
+			if port, err := strconv.Atoi(installer.HostPort(mapping)); err == nil {
+				ports = append(ports, port)
+			}
+		}
+	}
+	return ports
+}
+
+// installIDs returns the IDs the tool has state for: the default install
+// and named instances
+func installIDs(name string) []string {
+	var ids []string
+	for _, id := range stateIDs() {
+		if tool, _ := SplitInstance(id); tool == name {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// stateIDs returns the IDs of all installs with a receipt or a compose
+// directory, sorted
+func stateIDs() []string {
+	seen := make(map[string]bool)
+	home, _ := os.UserHomeDir()
+	dirs := []struct{ dir, suffix string }{
+		{config.DataDir("receipts"), ".json"},
+		{filepath.Join(home, ".getoai", "tools"), ""},
+	}
+	for _, d := range dirs {
+		entries, _ := os.ReadDir(d.dir)
+		for _, e := range entries {
+			if base, ok := strings.CutSuffix(e.Name(), d.suffix); ok && base != "" {
+				seen[strings.Replace(base, "@", ":", 1)] = true
+			}
+		}
+	}
+	ids := make([]string, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Instances returns the named instances getoai has installed, sorted by ID
+func Instances() []*Tool {
+	var instances []*Tool
+	for _, id := range stateIDs() {
+		if _, instance := SplitInstance(id); instance == "" {
+			continue
+		}
+		if t, ok := Get(id); ok {
+			instances = append(instances, t)
+		}
+	}
+	return instances
+}
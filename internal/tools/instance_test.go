@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetInstance(t *testing.T) {
+	tool, ok := Get("open-webui:staging")
+	if !ok {
+		t.Fatal("Get(open-webui:staging) not found")
+	}
+	if tool.Instance != "staging" || tool.ID() != "open-webui:staging" {
+		t.Errorf("Instance = %q, ID() = %q", tool.Instance, tool.ID())
+	}
+	if base, _ := Get("open-webui"); base.Instance != "" || base.ID() != "open-webui" {
+		t.Errorf("Get() of the instance changed the registry entry: %q", base.Instance)
+	}
+	if got := stateName(tool.ID()); got != "open-webui@staging" {
+		t.Errorf("stateName() = %q, want open-webui@staging", got)
+	}
+
+	for _, id := range []string{"open-webui:", "open-webui:Staging", "open-webui:-a", "open-webui:a_b", "open-webui:" + strings.Repeat("a", 33), "nosuch:staging"} {
+		if _, ok := Get(id); ok {
+			t.Errorf("Get(%q) found a tool", id)
+		}
+	}
+}
+
+func TestInstanceContainerSpec(t *testing.T) {
+	tool := &Tool{Name: "open-webui", Instance: "staging"}
+	config := InstallConfig{
+		Package:       "ghcr.io/open-webui/open-webui:main",
+		DockerName:    "open-webui",
+		DockerVolumes: []string{"open-webui-data:/app/backend/data", "/srv/models:/models:ro"},
+	}
+	spec, err := tool.containerSpec(config, nil, InstallOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.Name != "open-webui-staging" {
+		t.Errorf("Name = %q, want open-webui-staging", spec.Name)
+	}
+	want := "open-webui-data-staging:/app/backend/data,/srv/models:/models:ro"
+	if got := strings.Join(spec.Volumes, ","); got != want {
+		t.Errorf("Volumes = %s, want %s", got, want)
+	}
+	if config.DockerVolumes[0] != "open-webui-data:/app/backend/data" {
+		t.Errorf("registry volumes modified: %v", config.DockerVolumes)
+	}
+	if got := tool.composeProject(); got != "open-webui-staging" {
+		t.Errorf("composeProject() = %q", got)
+	}
+}
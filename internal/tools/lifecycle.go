@@ -0,0 +1,299 @@
+package tools
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/getoai/getoai-cli/internal/installer"
+	"github.com/getoai/getoai-cli/internal/platform"
+)
+
+// ServiceKind describes how an installed tool runs as a service
+type ServiceKind string
+
+const (
+	ServiceNone      ServiceKind = ""
+	ServiceContainer ServiceKind = "container" // single docker container
+	ServiceCompose   ServiceKind = "compose"   // docker compose app under ~/.getoai/tools
+	ServiceNative    ServiceKind = "native"    // background process, e.g. `ollama serve`
+)
+
+// ServiceStatus is the current state of a tool's service
+type ServiceStatus struct {
+	Kind    ServiceKind
+	Running bool
+	State   string // "running", "not ready" (failing its readiness probe), "stopped", or the container state reported by docker ("removed" if missing)
+	Detail  string // container name, compose directory or process ID
+}
+
+// ContainerName returns the name of the container getoai runs for this
+// tool, or an empty string if its docker method doesn't run a container.
+// A name chosen at install time (--name) is read from the receipt.
+func (t *Tool) ContainerName() string {
+	if spec := t.savedContainer(); spec != nil {
+		return spec.Name
+	}
+	config, ok := t.InstallMethods[installer.MethodDocker]
+	if !ok || config.DockerCompose != "" {
+		return ""
+	}
+	if config.DockerName != "" {
+		return t.instanceName(config.DockerName)
+	}
+	if len(config.DockerPorts) > 0 {
+		return t.instanceName(t.Name)
+	}
+	return ""
+}
+
+// savedContainer returns the container settings recorded at install time
+func (t *Tool) savedContainer() *installer.ContainerSpec {
+	if r := t.receipt(); r != nil {
+		return r.Container
+	}
+	return nil
+}
+
+// ServiceKind reports how the installed tool runs as a service, or
+// ServiceNone if it isn't installed in a way getoai can manage
+func (t *Tool) ServiceKind() ServiceKind {
+	if t.IsDockerComposeInstall() && t.GetComposeInstallDir() != "" {
+		return ServiceCompose
+	}
+	if t.IsDockerContainerInstalled() || t.savedContainer() != nil {
+		// A container removed outside getoai is recreated from the receipt
+		return ServiceContainer
+	}
+	if t.Instance == "" && t.Service != nil && len(t.Service.Command) > 0 && installer.CheckInstalled(t.Service.Command[0]) {
+		return ServiceNative
+	}
+	return ServiceNone
+}
+
+func (t *Tool) nativeService() *installer.NativeService {
+	return &installer.NativeService{
+		Name:    t.Name,
+		Command: t.Service.Command,
+		Env:     t.Service.Env,
+		Port:    t.Service.Port,
+	}
+}
+
+func (t *Tool) notAService() error {
+	if t.Service == nil && t.ContainerName() == "" && !t.IsDockerComposeInstall() {
+		return fmt.Errorf("%s does not run as a service", t.Name)
+	}
+	if r := t.receipt(); r != nil {
+		return fmt.Errorf("%s does not run as a service when installed with %s", t.Name, r.Method)
+	}
+	return fmt.Errorf("%s is not installed", t.Name)
+}
+
+// missingContainer returns the saved settings of a container that no
+// longer exists, so it can be recreated the way it was installed
+func (t *Tool) missingContainer() *installer.ContainerSpec {
+	spec := t.savedContainer()
+	if spec == nil || installer.ContainerState(spec.Name) != "" {
+		return nil
+	}
+	return spec
+}
+
+// Start starts the tool's container, compose app or background server
+func (t *Tool) Start() error {
+	dockerInst := installer.NewDockerInstaller()
+	switch t.ServiceKind() {
+	case ServiceCompose:
+		return dockerInst.ComposeUp(t.GetComposeInstallDir())
+	case ServiceContainer:
+		if spec := t.missingContainer(); spec != nil {
+			return dockerInst.RunContainer(*spec)
+		}
+		return dockerInst.StartContainer(t.ContainerName())
+	case ServiceNative:
+		if err := t.checkServiceArgs(); err != nil {
+			return err
+		}
+		return t.nativeService().Start()
+	}
+	return t.notAService()
+}
+
+// Stop stops the tool's service without removing it
+func (t *Tool) Stop() error {
+	dockerInst := installer.NewDockerInstaller()
+	switch t.ServiceKind() {
+	case ServiceCompose:
+		return dockerInst.Compose(t.GetComposeInstallDir(), "stop")
+	case ServiceContainer:
+		return dockerInst.StopContainer(t.ContainerName())
+	case ServiceNative:
+		return t.nativeService().Stop()
+	}
+	return t.notAService()
+}
+
+// Restart restarts the tool's service, starting it if it was stopped
+func (t *Tool) Restart() error {
+	dockerInst := installer.NewDockerInstaller()
+	switch t.ServiceKind() {
+	case ServiceCompose:
+		return dockerInst.Compose(t.GetComposeInstallDir(), "restart")
+	case ServiceContainer:
+		if spec := t.missingContainer(); spec != nil {
+			return dockerInst.RunContainer(*spec)
+		}
+		return dockerInst.RestartContainer(t.ContainerName())
+	case ServiceNative:
+		if err := t.checkServiceArgs(); err != nil {
+			return err
+		}
+		svc := t.nativeService()
+		if svc.Running() {
+			if err := svc.Stop(); err != nil {
+				return err
+			}
+		}
+		return svc.Start()
+	}
+	return t.notAService()
+}
+
+// Logs prints the last tail lines of the service's logs (all of them if
+// tail is 0), and keeps streaming new lines if follow is set
+func (t *Tool) Logs(follow bool, tail int) error {
+	switch t.ServiceKind() {
+	case ServiceCompose:
+		args := []string{"logs"}
+		if follow {
+			args = append(args, "-f")
+		}
+		if tail > 0 {
+			args = append(args, "--tail", strconv.Itoa(tail))
+		}
+		return installer.NewDockerInstaller().Compose(t.GetComposeInstallDir(), args...)
+	case ServiceContainer:
+		return installer.NewDockerInstaller().ContainerLogs(t.ContainerName(), follow, tail)
+	case ServiceNative:
+		return t.nativeService().Logs(follow, tail)
+	}
+	return t.notAService()
+}
+
+// Status returns the current state of the tool's service
+func (t *Tool) Status() ServiceStatus {
+	kind := t.ServiceKind()
+	status := ServiceStatus{Kind: kind, State: "stopped"}
+
+	switch kind {
+	case ServiceCompose:
+		status.Detail = t.GetComposeInstallDir()
+		status.Running = t.IsComposeRunning(status.Detail)
+	case ServiceContainer:
+		status.Detail = t.ContainerName()
+		status.State = installer.ContainerState(status.Detail)
+		if status.State == "" {
+			status.State = "removed"
+		}
+		status.Running = status.State == "running"
+	case ServiceNative:
+		svc := t.nativeService()
+		if pid := svc.PID(); pid != 0 {
+			status.Running = true
+			status.Detail = fmt.Sprintf("pid %d", pid)
+		} else if svc.Running() {
+			status.Running = true
+			status.Detail = fmt.Sprintf("port %d, not started by getoai", svc.Port)
+		}
+		if svc.Managed() {
+			status.Detail = strings.TrimPrefix(status.Detail+", systemd service", ", ")
+		}
+	case ServiceNone:
+		status.State = "not installed"
+		return status
+	}
+
+	if status.Running {
+		status.State = "running"
+		// Up, but not answering its readiness probe yet (or any more)
+		if probe := t.ReadyProbe(); probe != nil && probe.Check() != nil {
+			status.State = "not ready"
+		}
+	}
+	return status
+}
+
+// Upgrade pulls the latest image of a tool running as a container and
+// recreates the container with its current ports, env, volumes and labels.
+// The old container is kept stopped for Rollback.
+func (t *Tool) Upgrade() error {
+	name := t.ContainerName()
+	if name == "" || installer.ContainerState(name) == "" {
+		return fmt.Errorf("%s is not running as a container", t.Name)
+	}
+	config, ok := t.configFor(platform.Detect(), installer.MethodDocker)
+	if !ok {
+		return fmt.Errorf("%s has no docker install method", t.Name)
+	}
+
+	spec, err := installer.NewDockerInstaller().UpgradeContainer(name, config.Package)
+	if spec != nil {
+		// The new container is in place even if it failed its health check
+		t.recordInstall(installer.MethodDocker, spec)
+	}
+	return err
+}
+
+// UpgradeCompose moves a compose app to ref, or to the ref pinned in the
+// registry if ref is empty, and restarts it. review gets the changes to
+// the compose file and .env.example and can decline the update, in which
+// case nothing is changed and false is returned.
+func (t *Tool) UpgradeCompose(ref string, review func([]installer.ComposeChange) bool) (bool, error) {
+	installDir := t.GetComposeInstallDir()
+	if !t.IsDockerComposeInstall() || installDir == "" {
+		return false, fmt.Errorf("%s is not installed as a compose app", t.Name)
+	}
+	config, _ := t.configFor(platform.Detect(), installer.MethodDocker)
+	src := config.composeSource()
+	if ref != "" {
+		src.Ref = ref
+	}
+
+	dockerInst := installer.NewDockerInstaller()
+	applied, err := dockerInst.UpdateCompose(installDir, src, review)
+	if err != nil || !applied {
+		return false, err
+	}
+	return true, dockerInst.ComposeUp(installDir)
+}
+
+// ComposeSource returns the source the installed compose app was fetched
+// from, nil if unknown
+func (t *Tool) ComposeSource() *installer.ComposeSource {
+	if dir := t.GetComposeInstallDir(); dir != "" {
+		return installer.ReadComposeSource(dir)
+	}
+	return nil
+}
+
+// CanRollback reports whether an upgrade left a previous container behind
+func (t *Tool) CanRollback() bool {
+	name := t.ContainerName()
+	return name != "" && installer.ContainerState(installer.PreviousContainerName(name)) != ""
+}
+
+// Rollback switches back to the container kept by the last Upgrade
+func (t *Tool) Rollback() error {
+	name := t.ContainerName()
+	if name == "" {
+		return fmt.Errorf("%s does not run as a container", t.Name)
+	}
+	if err := installer.NewDockerInstaller().RollbackContainer(name); err != nil {
+		return err
+	}
+	if spec, err := installer.InspectContainer(name); err == nil {
+		t.recordInstall(installer.MethodDocker, spec)
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package tools
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/getoai/getoai-cli/internal/installer"
+	"github.com/getoai/getoai-cli/internal/platform"
+)
+
+// defaultStorageGB is the size of a volume claim when the registry doesn't
+// say how much disk a tool needs
+const defaultStorageGB = 5
+
+// InstallOptions overrides the registry's docker settings for one install.
+// Overrides are saved in the install receipt and reused by later updates.
+type InstallOptions struct {
+	// Method is the install method the user asked for, picked from the
+	// available ones if empty. It is not a container override.
+	Method installer.InstallMethod
+
+	Name    string            // container name
+	Ports   []string          // "host:container" mappings, or a bare host port for the first mapping
+	Env     map[string]string // environment variables, merged into the defaults
+	Volumes []string          // volume mappings, replacing defaults with the same target
+
+	// BackendEnv adds to the tool's BackendEnv templates, see Stack
+	BackendEnv map[string]string
+
+	// ChoosePort picks a replacement when a host port is busy; without it
+	// a busy port aborts the install
+	ChoosePort installer.PortChooser
+}
+
+// IsZero reports whether no container override is set
+func (o InstallOptions) IsZero() bool {
+	return o.Name == "" && len(o.Ports) == 0 && len(o.Env) == 0 && len(o.Volumes) == 0 && len(o.BackendEnv) == 0
+}
+
+// containerSpec builds the container settings for an install: the registry
+// defaults, then the settings saved in the previous receipt, then opts
+func (t *Tool) containerSpec(config InstallConfig, prev *installer.ContainerSpec, opts InstallOptions) (installer.ContainerSpec, error) {
+	spec := installer.ContainerSpec{
+		Name:    config.DockerName,
+		Image:   config.Package,
+		Ports:   append([]string(nil), config.DockerPorts...),
+		Env:     make(map[string]string),
+		Volumes: append([]string(nil), config.DockerVolumes...),
+	}
+	if spec.Name == "" {
+		spec.Name = t.Name
+	}
+	for k, v := range config.DockerEnv {
+		spec.Env[k] = v
+	}
+	t.instanceSpec(&spec)
+
+	if prev != nil {
+		spec.Name = prev.Name
+		spec.Ports = append([]string(nil), prev.Ports...)
+		spec.Volumes = append([]string(nil), prev.Volumes...)
+		for k, v := range prev.Env {
+			spec.Env[k] = v
+		}
+	}
+
+	if opts.Name != "" {
+		spec.Name = opts.Name
+	}
+	for _, port := range opts.Ports {
+		ports, err := mergePort(spec.Ports, port)
+		if err != nil {
+			return spec, err
+		}
+		spec.Ports = ports
+	}
+	for k, v := range opts.Env {
+		spec.Env[k] = v
+	}
+	for _, vol := range opts.Volumes {
+		spec.Volumes = mergeVolume(spec.Volumes, vol)
+	}
+
+	if len(spec.Env) == 0 {
+		spec.Env = nil
+	}
+	return spec, nil
+}
+
+// ExportSpec returns the settings of a single-container tool for export:
+// the ones recorded at install time, those of its running container, or
+// the registry defaults if it isn't installed. Compose apps with an
+// ExportSpec in the registry export that.
+func (t *Tool) ExportSpec() (installer.ContainerSpec, error) {
+	if spec := t.savedContainer(); spec != nil {
+		return *spec, nil
+	}
+	config, ok := t.configFor(platform.Detect(), installer.MethodDocker)
+	compose := config.DockerCompose != ""
+	if ok && compose {
+		ok = config.ExportSpec != nil
+		if ok {
+			export := *config.ExportSpec
+			if export.Package == "" {
+				export.Package = config.Package
+			}
+			config = export
+		}
+	}
+	if !ok || len(config.DockerPorts) == 0 {
+		return installer.ContainerSpec{}, fmt.Errorf("%s does not run as a single container", t.Name)
+	}
+	if !compose && t.IsDockerContainerInstalled() {
+		if spec, err := installer.InspectContainer(t.ContainerName()); err == nil {
+			return *spec, nil
+		}
+	}
+	spec, err := t.containerSpec(config, nil, InstallOptions{})
+	spec.Network = installer.NetworkName
+	return spec, err
+}
+
+// K8sApp returns the tool's container settings for a Kubernetes
+// deployment, with the requests and probe taken from the registry
+func (t *Tool) K8sApp() (installer.K8sApp, error) {
+	spec, err := t.ExportSpec()
+	if err != nil {
+		return installer.K8sApp{}, err
+	}
+	app := installer.K8sApp{
+		Name:     t.Name,
+		Spec:     spec,
+		MemoryGB: t.Resources.MemoryGB,
+		CPUCores: t.Resources.CPUCores,
+		// The disk requirement includes the image, which isn't stored in
+		// the claims
+		StorageGB: max(int(math.Ceil(t.Resources.DiskGB-t.Resources.DownloadGB)), defaultStorageGB),
+	}
+	for key := range spec.Env {
+		if t.IsSecretEnv(key) {
+			app.SecretEnv = append(app.SecretEnv, key)
+		}
+	}
+	if t.Ready != nil && len(t.Ready.Command) == 0 {
+		app.ReadyHTTP = t.Ready.HTTP
+		app.ReadyPort = t.Ready.Port
+	}
+	return app, nil
+}
+
+// mergePort applies a port override. A full mapping replaces the mapping of
+// the same container port, or is added; a bare host port remaps the first
+// (main) port.
+func mergePort(ports []string, override string) ([]string, error) {
+	if override == "" {
+		return nil, fmt.Errorf("empty port mapping")
+	}
+	merged := append([]string(nil), ports...)
+
+	if !strings.Contains(override, ":") {
+		if len(merged) == 0 {
+			return append(merged, override+":"+override), nil
+		}
+		merged[0] = override + ":" + installer.ContainerPort(merged[0])
+		return merged, nil
+	}
+
+	target := installer.ContainerPort(override)
+	for i, p := range merged {
+		if installer.ContainerPort(p) == target {
+			merged[i] = override
+			return merged, nil
+		}
+	}
+	return append(merged, override), nil
+}
+
+// mergeVolume replaces the volume mounted at the same container path, or
+// adds the override
+func mergeVolume(volumes []string, override string) []string {
+	merged := append([]string(nil), volumes...)
+	target := installer.VolumeTarget(override)
+	for i, v := range merged {
+		if installer.VolumeTarget(v) == target {
+			merged[i] = override
+			return merged
+		}
+	}
+	return append(merged, override)
+}
@@ -0,0 +1,82 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getoai/getoai-cli/internal/installer"
+)
+
+func TestMergePort(t *testing.T) {
+	tests := []struct {
+		name     string
+		ports    []string
+		override string
+		want     string
+	}{
+		{name: "Replace same container port", ports: []string{"3000:8080"}, override: "3001:8080", want: "3001:8080"},
+		{name: "Add new container port", ports: []string{"3000:8080"}, override: "9000:9000", want: "3000:8080,9000:9000"},
+		{name: "Bare host port remaps main port", ports: []string{"3000:8080", "9000:9000"}, override: "3001", want: "3001:8080,9000:9000"},
+		{name: "Bare host port without defaults", ports: nil, override: "8000", want: "8000:8000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergePort(tt.ports, tt.override)
+			if err != nil {
+				t.Fatalf("mergePort() error = %v", err)
+			}
+			if strings.Join(got, ",") != tt.want {
+				t.Errorf("mergePort(%v, %q) = %v, want %s", tt.ports, tt.override, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainerSpecLayering(t *testing.T) {
+	tool := &Tool{Name: "open-webui"}
+	config := InstallConfig{
+		Package:       "ghcr.io/open-webui/open-webui:main",
+		DockerName:    "open-webui",
+		DockerPorts:   []string{"3000:8080"},
+		DockerEnv:     map[string]string{"WEBUI_AUTH": "True"},
+		DockerVolumes: []string{"open-webui:/app/backend/data"},
+	}
+
+	// Settings from the previous install are kept on update
+	prev := &installer.ContainerSpec{
+		Name:    "webui",
+		Ports:   []string{"3001:8080"},
+		Env:     map[string]string{"WEBUI_AUTH": "False"},
+		Volumes: []string{"open-webui:/app/backend/data"},
+	}
+	spec, err := tool.containerSpec(config, prev, InstallOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.Name != "webui" || spec.Ports[0] != "3001:8080" || spec.Env["WEBUI_AUTH"] != "False" {
+		t.Errorf("previous settings not kept: %+v", spec)
+	}
+	if spec.Image != config.Package {
+		t.Errorf("Image = %q, want registry image %q", spec.Image, config.Package)
+	}
+
+	// Explicit options win over both
+	spec, err = tool.containerSpec(config, prev, InstallOptions{
+		Ports:   []string{"4000:8080"},
+		Env:     map[string]string{"OLLAMA_BASE_URL": "http://host.docker.internal:11434"},
+		Volumes: []string{"/srv/webui:/app/backend/data"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(spec.Ports, ","); got != "4000:8080" {
+		t.Errorf("Ports = %s, want 4000:8080", got)
+	}
+	if got := strings.Join(spec.Volumes, ","); got != "/srv/webui:/app/backend/data" {
+		t.Errorf("Volumes = %s, want the override", got)
+	}
+	if spec.Env["WEBUI_AUTH"] != "False" || spec.Env["OLLAMA_BASE_URL"] == "" {
+		t.Errorf("Env not merged: %v", spec.Env)
+	}
+}
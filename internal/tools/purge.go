@@ -0,0 +1,155 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/getoai/getoai-cli/internal/installer"
+	"github.com/getoai/getoai-cli/internal/platform"
+)
+
+// PurgeItem is data left behind by an uninstall, deleted with --purge
+type PurgeItem struct {
+	Kind string // "path", "volume" or "image"
+	Name string // absolute path, volume name or image reference
+	Size int64  // in bytes, -1 if unknown
+}
+
+// Remove deletes the item
+func (p PurgeItem) Remove() error {
+	switch p.Kind {
+	case "volume":
+		return installer.RemoveVolume(p.Name)
+	case "image":
+		return installer.RemoveImage(p.Name)
+	default:
+		return installer.RemovePath(p.Name)
+	}
+}
+
+// PurgePlan lists what uninstalling the tool leaves behind, as far as it
+// still exists: its declared data paths and server log, its compose
+// directory, and the named volumes and images of its containers. Host
+// directories mounted into a container are the user's and not listed.
+func (t *Tool) PurgePlan() []PurgeItem {
+	var items []PurgeItem
+	seen := make(map[string]bool)
+	add := func(kind, name string, size int64) {
+		if name == "" || seen[kind+":"+name] {
+			return
+		}
+		seen[kind+":"+name] = true
+		items = append(items, PurgeItem{Kind: kind, Name: name, Size: size})
+	}
+
+	var paths []string
+	if t.Instance == "" {
+		// A named instance only has docker data
+		for _, pattern := range t.DataPaths {
+			paths = append(paths, expandDataPath(pattern)...)
+		}
+		if t.Service != nil {
+			paths = append(paths, t.nativeService().LogFile())
+		}
+	}
+	if dir := t.GetComposeInstallDir(); dir != "" && t.IsDockerComposeInstall() {
+		paths = append(paths, dir)
+	}
+	for _, path := range paths {
+		if _, err := os.Lstat(path); err == nil {
+			add("path", path, installer.PathSize(path))
+		}
+	}
+
+	if _, ok := t.InstallMethods[installer.MethodDocker]; !ok {
+		return items
+	}
+	volumes, images := t.purgeDockerData()
+	sizes := installer.VolumeSizes()
+	for _, v := range volumes {
+		if size, ok := sizes[v]; ok {
+			add("volume", v, size)
+		}
+	}
+	for _, image := range images {
+		if size := installer.ImageSize(image); size >= 0 {
+			add("image", image, size)
+		}
+	}
+	return items
+}
+
+// purgeDockerData returns the named volumes and images the tool's
+// containers use: those of the installed container or compose app, or
+// the registry defaults if its container is gone but it was installed
+// with docker
+func (t *Tool) purgeDockerData() (volumes, images []string) {
+	if t.IsDockerComposeInstall() {
+		composeFile := installer.FindComposeFile(t.GetComposeInstallDir())
+		if composeFile == "" {
+			return nil, nil
+		}
+		if _, project, err := installer.ComposeProject(composeFile); err == nil {
+			for _, key := range sortedMapKeys(project) {
+				volumes = append(volumes, project[key])
+			}
+		}
+		return volumes, installer.ComposeImages(composeFile)
+	}
+
+	var specs []*installer.ContainerSpec
+	if spec, err := t.currentContainer(); err == nil {
+		specs = append(specs, spec)
+		// The container kept for rollback may still run the previous image
+		if prev, err := installer.InspectContainer(installer.PreviousContainerName(spec.Name)); err == nil {
+			specs = append(specs, prev)
+		}
+	} else if r := t.receipt(); r != nil && r.Method == installer.MethodDocker {
+		// Tools installed some other way don't have the registry's image
+		// and volumes as their data
+		if config, ok := t.configFor(platform.Detect(), installer.MethodDocker); ok && config.Package != "" {
+			spec := &installer.ContainerSpec{Image: config.Package, Volumes: append([]string(nil), config.DockerVolumes...)}
+			t.instanceSpec(spec)
+			specs = append(specs, spec)
+		}
+	}
+	for _, spec := range specs {
+		for _, v := range spec.Volumes {
+			if source := installer.VolumeSource(v); installer.IsNamedVolume(source) {
+				volumes = append(volumes, source)
+			}
+		}
+		images = append(images, spec.Image)
+	}
+	return volumes, images
+}
+
+// expandDataPath resolves a DataPaths pattern such as "~/.aider*" to the
+// existing paths it matches
+func expandDataPath(pattern string) []string {
+	home, err := os.UserHomeDir()
+	rest, ok := strings.CutPrefix(pattern, "~/")
+	if err != nil || !ok || rest == "" {
+		return nil
+	}
+	matches, _ := filepath.Glob(filepath.Join(home, filepath.FromSlash(rest)))
+	sort.Strings(matches)
+	return matches
+}
+
+// PurgeSize returns the total size of the items, and whether some sizes
+// are unknown
+func PurgeSize(items []PurgeItem) (int64, bool) {
+	var total int64
+	partial := false
+	for _, item := range items {
+		if item.Size < 0 {
+			partial = true
+			continue
+		}
+		total += item.Size
+	}
+	return total, partial
+}
@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExpandDataPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	for _, name := range []string{".aider", ".aider.conf.yml", ".bashrc"} {
+		os.WriteFile(filepath.Join(home, name), nil, 0600)
+	}
+
+	got := expandDataPath("~/.aider*")
+	want := []string{filepath.Join(home, ".aider"), filepath.Join(home, ".aider.conf.yml")}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expandDataPath(~/.aider*) = %v, want %v", got, want)
+	}
+	for _, pattern := range []string{"~/", "/etc/passwd", "~/.missing"} {
+		if got := expandDataPath(pattern); len(got) != 0 {
+			t.Errorf("expandDataPath(%q) = %v, want none", pattern, got)
+		}
+	}
+}
+
+func TestDataPathsAreInHome(t *testing.T) {
+	for _, tool := range List() {
+		for _, p := range tool.DataPaths {
+			rest, ok := strings.CutPrefix(p, "~/")
+			if !ok || rest == "" || strings.HasPrefix(rest, "*") || strings.Contains(p, "..") {
+				t.Errorf("%s: data path %q must name a path inside ~/", tool.Name, p)
+			}
+		}
+	}
+}
+
+func TestPurgeSize(t *testing.T) {
+	items := []PurgeItem{
+		{Kind: "path", Name: "/a", Size: 100},
+		{Kind: "volume", Name: "v", Size: -1},
+		{Kind: "image", Name: "i", Size: 50},
+	}
+	if total, partial := PurgeSize(items); total != 150 || !partial {
+		t.Errorf("PurgeSize() = %d, %v, want 150, true", total, partial)
+	}
+	if total, partial := PurgeSize(items[:1]); total != 100 || partial {
+		t.Errorf("PurgeSize() = %d, %v, want 100, false", total, partial)
+	}
+}
@@ -0,0 +1,132 @@
+package tools
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/getoai/getoai-cli/internal/installer"
+	"github.com/getoai/getoai-cli/internal/platform"
+)
+
+// defaultReadyTimeout is how long a service may take to become ready
+const defaultReadyTimeout = 2 * time.Minute
+
+// ReadyProbe returns the readiness probe of the installed service, or nil
+// if there is nothing to probe, e.g. a compose app without published ports
+func (t *Tool) ReadyProbe() *installer.Probe {
+	var check ReadyCheck
+	if t.Ready != nil {
+		check = *t.Ready
+	}
+	probe := &installer.Probe{HTTP: check.HTTP, Command: check.Command}
+	if len(check.Command) > 0 {
+		return probe
+	}
+
+	probe.Port = t.hostPort(check.Port)
+	if probe.Port == 0 {
+		return nil
+	}
+	return probe
+}
+
+// hostPort returns the host port a service is reached on: the one
+// publishing the given container port, the given port of compose apps and
+// native servers, or the main port if port is 0. It returns 0 if unknown.
+func (t *Tool) hostPort(port int) int {
+	switch t.ServiceKind() {
+	case ServiceContainer:
+		return t.containerHostPort(port)
+	case ServiceCompose:
+		if port != 0 {
+			return port
+		}
+		composeFile := installer.FindComposeFile(t.GetComposeInstallDir())
+		if ports, err := installer.ComposePorts(composeFile); err == nil && len(ports) > 0 {
+			return ports[0].Port
+		}
+	case ServiceNative:
+		if port != 0 {
+			return port
+		}
+		return t.Service.Port
+	}
+	return 0
+}
+
+// containerHostPort returns the host port publishing containerPort, or
+// the first published port if containerPort is 0
+func (t *Tool) containerHostPort(containerPort int) int {
+	var ports []string
+	if spec := t.savedContainer(); spec != nil {
+		ports = spec.Ports
+	} else if config, ok := t.configFor(platform.Detect(), installer.MethodDocker); ok {
+		ports = config.DockerPorts
+	}
+	for _, mapping := range ports {
+		if containerPort != 0 && installer.ContainerPort(mapping) != strconv.Itoa(containerPort) {
+			continue
+		}
+		port, _ := strconv.Atoi(installer.HostPort(mapping))
+		return port
+	}
+	return 0
+}
+
+// WaitReady waits until the tool's service passes its readiness probe. It
+// fails early if a container fails or the server process dies.
+func (t *Tool) WaitReady() error {
+	probe := t.ReadyProbe()
+	if probe == nil {
+		return nil
+	}
+	timeout := defaultReadyTimeout
+	if t.Ready != nil && t.Ready.Timeout > 0 {
+		timeout = t.Ready.Timeout
+	}
+
+	var alive func() error
+	switch t.ServiceKind() {
+	case ServiceContainer:
+		alive = installer.ContainerAlive(t.ContainerName())
+	case ServiceCompose:
+		alive = installer.ComposeAlive(installer.FindComposeFile(t.GetComposeInstallDir()))
+	case ServiceNative:
+		svc := t.nativeService()
+		alive = func() error {
+			if !svc.Running() {
+				return fmt.Errorf("%s exited", svc.Command[0])
+			}
+			return nil
+		}
+	}
+	return installer.WaitReady(*probe, timeout, alive)
+}
+
+// AccessURL returns the URL of the tool's web UI: its WebURL template with
+// {port} replaced by the main host port, or http://localhost:<port>.
+// Native servers need a WebURL, their port is usually an API.
+func (t *Tool) AccessURL() (string, error) {
+	kind := t.ServiceKind()
+	if kind == ServiceNone {
+		return "", t.notAService()
+	}
+	if kind == ServiceNative && t.WebURL == "" {
+		return "", fmt.Errorf("%s has no web UI", t.Name)
+	}
+
+	port := t.hostPort(0)
+	if port == 0 {
+		if t.WebURL != "" && !strings.Contains(t.WebURL, "{port}") {
+			return t.WebURL, nil
+		}
+		return "", fmt.Errorf("%s does not publish a port", t.Name)
+	}
+	template := t.WebURL
+	if template == "" {
+		template = "http://localhost:{port}"
+	}
+	return strings.ReplaceAll(template, "{port}", strconv.Itoa(port)), nil
+}
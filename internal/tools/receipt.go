@@ -0,0 +1,85 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/getoai/getoai-cli/internal/config"
+	"github.com/getoai/getoai-cli/internal/installer"
+)
+
+// Receipt records how a tool was installed, so later commands (update,
+// restart, ...) can reproduce the same setup. Receipts are stored as JSON
+// in ~/.getoai/receipts.
+type Receipt struct {
+	Tool        string                   `json:"tool"`
+	Method      installer.InstallMethod  `json:"method"`
+	InstalledAt time.Time                `json:"installed_at"`
+	Container   *installer.ContainerSpec `json:"container,omitempty"`
+}
+
+func receiptPath(id string) string {
+	return config.DataDir("receipts", stateName(id)+".json")
+}
+
+// LoadReceipt returns the install receipt of a tool or "<tool>:<instance>",
+// or nil if there is none
+func LoadReceipt(name string) (*Receipt, error) {
+	data, err := os.ReadFile(receiptPath(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var r Receipt
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("invalid receipt for %s: %w", name, err)
+	}
+	return &r, nil
+}
+
+// Save writes the receipt, replacing any previous one for the tool
+func (r *Receipt) Save() error {
+	path := receiptPath(r.Tool)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create receipts directory: %w", err)
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// RemoveReceipt deletes the install receipt of a tool, if any
+func RemoveReceipt(name string) error {
+	err := os.Remove(receiptPath(name))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// receipt returns the tool's install receipt, ignoring unreadable ones
+func (t *Tool) receipt() *Receipt {
+	r, _ := LoadReceipt(t.ID())
+	return r
+}
+
+// recordInstall saves the install receipt after a successful install. A
+// failure is only reported, the tool itself is installed.
+func (t *Tool) recordInstall(method installer.InstallMethod, spec *installer.ContainerSpec) {
+	r := &Receipt{
+		Tool:        t.ID(),
+		Method:      method,
+		InstalledAt: time.Now().UTC(),
+		Container:   spec,
+	}
+	if err := r.Save(); err != nil {
+		fmt.Printf("Warning: failed to save install receipt: %v\n", err)
+	}
+}
@@ -3,9 +3,9 @@ package tools
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/getoai/getoai-cli/internal/installer"
 	"github.com/getoai/getoai-cli/internal/platform"
@@ -33,26 +33,113 @@ type Tool struct {
 	// Installation options by method
 	InstallMethods map[installer.InstallMethod]InstallConfig
 
-	// Platform-specific overrides
+	// Minimum system resources, checked before installing
+	Resources Resources
+
+	// Long-running server for natively installed tools, managed with
+	// getoai start/stop/restart/status/logs
+	Service *ServiceConfig
+
+	// How to tell that the service is ready after it starts
+	Ready *ReadyCheck
+
+	// Web UI address, with {port} standing for the main host port, e.g.
+	// "http://localhost:{port}/ui"; http://localhost:<port> if empty
+	WebURL string
+
+	// Env vars pointing the tool's container at local model servers, set
+	// when those are installed. {name} stands for the base URL of that
+	// tool as seen from the container, e.g. "OLLAMA_BASE_URL": "{ollama}".
+	BackendEnv map[string]string
+
+	// Data and config the tool keeps outside its install, deleted by
+	// uninstall --purge. Paths start with ~/ and may be globs, e.g.
+	// "~/.aider*".
+	DataPaths []string
+
+	// Relations to other tools
+	Requires   []string // tools or capabilities that must be installed first
+	Recommends []string // tools that work well together, installed with --with-recommended
+	Conflicts  []string // tools or capabilities that can't be installed alongside
+	Provides   []string // capabilities this tool provides, e.g. "docker-engine"
+
+	// Platform-specific overrides, keyed by platform selector
+	// ("linux", "linux/arm64", "linux:debian", "wsl", ...)
 	PlatformOverrides map[string]map[installer.InstallMethod]InstallConfig
+
+	// Named instance of a docker tool, set by Get for "<tool>:<instance>";
+	// empty for the default install
+	Instance string
+}
+
+// Resources describes the system resources a tool needs to run. Zero
+// values mean no requirement.
+type Resources struct {
+	MemoryGB   float64 // minimum total RAM
+	DiskGB     float64 // minimum free disk space, including the download
+	CPUCores   int     // recommended CPU cores
+	DownloadGB float64 // approximate image or download size
+}
+
+// ServiceConfig describes how to run a natively installed tool as a
+// background server, e.g. `ollama serve`
+type ServiceConfig struct {
+	Command  []string          // command line, e.g. ["ollama", "serve"]
+	Env      map[string]string // extra environment variables
+	Port     int               // port the server listens on
+	Restart  string            // systemd restart policy of the service unit, "on-failure" if empty
+	HostHint string            // how to make the server listen on all interfaces, e.g. "OLLAMA_HOST=0.0.0.0:11434"; empty if it does
+	ArgsHint string            // arguments the user must add, e.g. "-m <model.gguf>"; empty if none
+}
+
+// ReadyCheck declares how to tell that a tool's service is ready to use.
+// Without one, getoai waits for the service's main port to accept
+// connections.
+type ReadyCheck struct {
+	HTTP    string        // path answering with a status below 500 once ready, e.g. "/health"
+	Port    int           // container port, published compose port or native listen port; the main port if 0
+	Command []string      // command run on the host that exits 0 once ready, used instead of HTTP/TCP
+	Timeout time.Duration // how long startup may take, 2 minutes if 0
 }
 
 type InstallConfig struct {
 	Package string   // package name or URL
 	Args    []string // additional arguments
 
+	// Minimum runtime versions needed by this method, keyed by runtime
+	// ("node", "python", "go", "docker", "compose"), e.g. {"node": ">=18"}
+	Runtimes map[string]string
+
+	// Tools or capabilities this method needs installed first, on top of
+	// the tool's Requires
+	Requires []string
+
 	// Docker-specific options
-	DockerPorts   []string          // port mappings, e.g. ["3000:3000", "8080:80"]
-	DockerEnv     map[string]string // environment variables
-	DockerVolumes []string          // volume mappings
-	DockerName    string            // container name
-	DockerCompose string            // docker-compose repo URL (for complex apps)
+	DockerPorts    []string          // port mappings, e.g. ["3000:3000", "8080:80"]
+	DockerEnv      map[string]string // environment variables
+	DockerVolumes  []string          // volume mappings
+	DockerName     string            // container name
+	DockerCompose  string            // docker-compose repo URL (for complex apps)
+	ComposeRef     string            // tag or branch of DockerCompose to install, the default branch if empty
+	ComposePath    string            // directory of DockerCompose holding the compose file, fetched on its own
+	ComposeFile    string            // compose file in ComposePath when it has several, e.g. "docker-compose-pgvector.yml"
+	ComposeSecrets map[string]int    // .env keys set to random hex strings of that many bytes at install
+	SecretRefs     map[string]string // .env keys that repeat ComposeSecrets, e.g. "redis://:{REDIS_PASSWORD}@redis:6379/1"
+
+	// Single-container equivalent of a DockerCompose app, only used by
+	// export. Its Package defaults to the app's.
+	ExportSpec *InstallConfig
 
 	// Download-specific options (for desktop apps)
-	DownloadURLs map[string]string // platform-specific download URLs: "darwin", "linux", "windows"
+	DownloadURLs map[string]string // download URLs keyed by platform selector: "darwin", "darwin/arm64", "linux/amd64", ...
 	FileType     string            // file type: "dmg", "pkg", "deb", "appimage", "exe", "msi"
 }
 
+// composeSource returns the pinned part of the DockerCompose repository
+func (c InstallConfig) composeSource() installer.ComposeSource {
+	return installer.ComposeSource{Repo: c.DockerCompose, Ref: c.ComposeRef, Path: c.ComposePath, File: c.ComposeFile}
+}
+
 var registry = map[string]*Tool{}
 
 func init() {
@@ -109,6 +196,7 @@ func init() {
 	// Development tools
 	registerNvm()
 	registerNode()
+	registerGo()
 	registerDocker()
 	registerDockerCompose()
 	registerGitHubCLI()
@@ -159,6 +247,9 @@ func registerOllama() {
 		Category:    CategoryLLM,
 		Website:     "https://ollama.ai",
 		Command:     "ollama",
+		DataPaths:   []string{"~/.ollama"},
+		Service:     &ServiceConfig{Command: []string{"ollama", "serve"}, Port: 11434, HostHint: "OLLAMA_HOST=0.0.0.0:11434"},
+		Ready:       &ReadyCheck{HTTP: "/api/version"},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodDownload: {Package: "https://ollama.ai/download"},
 			installer.MethodBrew:     {Package: "ollama"},
@@ -187,8 +278,9 @@ func registerClaudeCode() {
 		Category:    CategoryCoding,
 		Website:     "https://claude.ai",
 		Command:     "claude",
+		DataPaths:   []string{"~/.claude", "~/.claude.json"},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
-			installer.MethodNpm: {Package: "@anthropic-ai/claude-code"},
+			installer.MethodNpm: {Package: "@anthropic-ai/claude-code", Runtimes: map[string]string{"node": ">=18"}},
 		},
 	})
 }
@@ -200,6 +292,7 @@ func registerOpenAICLI() {
 		Category:    CategoryUtility,
 		Website:     "https://platform.openai.com",
 		Command:     "openai",
+		Conflicts:   []string{"chatgpt-cli"},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodPip: {Package: "openai"},
 		},
@@ -213,8 +306,9 @@ func registerAider() {
 		Category:    CategoryCoding,
 		Website:     "https://aider.chat",
 		Command:     "aider",
+		DataPaths:   []string{"~/.aider*"},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
-			installer.MethodPip:  {Package: "aider-chat"},
+			installer.MethodPip:  {Package: "aider-chat", Runtimes: map[string]string{"python": ">=3.9"}},
 			installer.MethodBrew: {Package: "aider"},
 		},
 	})
@@ -227,6 +321,7 @@ func registerLLM() {
 		Category:    CategoryUtility,
 		Website:     "https://llm.datasette.io",
 		Command:     "llm",
+		DataPaths:   []string{"~/.config/io.datasette.llm", "~/Library/Application Support/io.datasette.llm"},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodPip:  {Package: "llm"},
 			installer.MethodBrew: {Package: "llm"},
@@ -241,8 +336,15 @@ func registerOpenWebUI() {
 		Category:    CategoryUI,
 		Website:     "https://openwebui.com",
 		Command:     "open-webui",
+		Resources:   Resources{MemoryGB: 2, DiskGB: 10, DownloadGB: 4},
+		Recommends:  []string{"ollama"},
+		Ready:       &ReadyCheck{HTTP: "/health", Port: 8080, Timeout: 5 * time.Minute},
+		BackendEnv: map[string]string{
+			"OLLAMA_BASE_URL":     "{ollama}",
+			"OPENAI_API_BASE_URL": "{localai}/v1",
+		},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
-			installer.MethodPip: {Package: "open-webui"},
+			installer.MethodPip: {Package: "open-webui", Runtimes: map[string]string{"python": ">=3.11,<3.13"}},
 			installer.MethodDocker: {
 				Package:     "ghcr.io/open-webui/open-webui:main",
 				DockerName:  "open-webui",
@@ -262,6 +364,7 @@ func registerChatGPTCLI() {
 		Category:    CategoryUtility,
 		Website:     "https://github.com/kardolus/chatgpt-cli",
 		Command:     "chatgpt",
+		DataPaths:   []string{"~/.chatgpt-cli"},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodGo:   {Package: "github.com/kardolus/chatgpt-cli/cmd/chatgpt"},
 			installer.MethodBrew: {Package: "kardolus/chatgpt-cli/chatgpt-cli"},
@@ -282,9 +385,11 @@ func registerCursor() {
 			installer.MethodDownload: {
 				Package: "https://cursor.sh",
 				DownloadURLs: map[string]string{
-					"darwin":  "https://downloader.cursor.sh/mac/universal",
-					"linux":   "https://downloader.cursor.sh/linux/appImage/x64",
-					"windows": "https://downloader.cursor.sh/windows/nsis/x64",
+					"darwin":        "https://downloader.cursor.sh/mac/universal",
+					"linux":         "https://downloader.cursor.sh/linux/appImage/x64",
+					"linux/arm64":   "https://downloader.cursor.sh/linux/appImage/arm64",
+					"windows":       "https://downloader.cursor.sh/windows/nsis/x64",
+					"windows/arm64": "https://downloader.cursor.sh/windows/nsis/arm64",
 				},
 			},
 		},
@@ -316,9 +421,20 @@ func Register(tool *Tool) {
 	registry[tool.Name] = tool
 }
 
+// Get returns a tool by name. For "<tool>:<instance>" it returns a copy
+// addressing that named instance.
 func Get(name string) (*Tool, bool) {
+	name, instance, named := strings.Cut(name, ":")
 	tool, ok := registry[name]
-	return tool, ok
+	if !ok || !named {
+		return tool, ok
+	}
+	if ValidInstance(instance) != nil {
+		return nil, false
+	}
+	t := *tool
+	t.Instance = instance
+	return &t, true
 }
 
 func List() []*Tool {
@@ -354,6 +470,10 @@ func (t *Tool) IsInstalled() bool {
 	if t.IsDockerContainerInstalled() {
 		return true
 	}
+	if t.Instance != "" {
+		// Only the docker install is namespaced
+		return false
+	}
 
 	// Check desktop apps (by AppName)
 	if t.AppName != "" {
@@ -431,35 +551,19 @@ func (t *Tool) IsDesktopAppInstalled() bool {
 
 // IsComposeRunning checks if docker-compose containers are running
 func (t *Tool) IsComposeRunning(installDir string) bool {
-	// Find compose file
-	locations := []string{
-		"docker/docker-compose.yaml",
-		"docker/docker-compose.yml",
-		"docker-compose.yaml",
-		"docker-compose.yml",
-	}
-
-	var composeFile string
-	for _, loc := range locations {
-		path := fmt.Sprintf("%s/%s", installDir, loc)
-		if _, err := os.Stat(path); err == nil {
-			composeFile = path
-			break
-		}
-	}
-
+	composeFile := installer.FindComposeFile(installDir)
 	if composeFile == "" {
 		return false
 	}
 
 	// Check running containers using --status=running filter
-	out, err := installer.RunCommandSilent("docker", "compose", "-f", composeFile, "ps", "--status=running", "-q")
+	out, err := installer.ComposeOutput(composeFile, "ps", "--status=running", "-q")
 	if err != nil {
 		return false
 	}
 
 	// Filter out warning lines (only keep container IDs which are hex strings)
-	lines := strings.Split(strings.TrimSpace(out), "\n")
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
 		// Container IDs are 12 or 64 character hex strings
@@ -490,21 +594,14 @@ func (t *Tool) IsDockerComposeInstall() bool {
 
 // IsDockerContainerInstalled checks if a Docker container is running for this tool
 func (t *Tool) IsDockerContainerInstalled() bool {
-	// Check if tool has docker installation method with container name
-	config, hasDocker := t.InstallMethods[installer.MethodDocker]
-	if !hasDocker || config.DockerName == "" {
+	// Check if tool has docker installation method with a container
+	containerName := t.ContainerName()
+	if containerName == "" {
 		return false
 	}
 
 	// Check if container exists (running or stopped)
-	cmd := exec.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=^%s$", config.DockerName), "--format", "{{.ID}}")
-	out, err := cmd.Output()
-	if err != nil {
-		return false
-	}
-
-	containerID := strings.TrimSpace(string(out))
-	return containerID != ""
+	return installer.ContainerState(containerName) != ""
 }
 
 // GetComposeInstallDir returns the install directory if it exists, empty string otherwise
@@ -513,7 +610,7 @@ func (t *Tool) GetComposeInstallDir() string {
 	if err != nil {
 		return ""
 	}
-	installDir := fmt.Sprintf("%s/.getoai/tools/%s", homeDir, t.Name)
+	installDir := fmt.Sprintf("%s/.getoai/tools/%s", homeDir, stateName(t.ID()))
 	if _, err := os.Stat(installDir); err == nil {
 		return installDir
 	}
@@ -531,33 +628,63 @@ func (t *Tool) GetVersion() string {
 }
 
 func (t *Tool) Install(preferredMethod installer.InstallMethod) error {
+	return t.InstallWithOptions(preferredMethod, InstallOptions{})
+}
+
+// InstallWithOptions installs the tool, applying docker overrides to tools
+// that run as a container, and records an install receipt on success
+func (t *Tool) InstallWithOptions(preferredMethod installer.InstallMethod, opts InstallOptions) error {
 	p := platform.Detect()
 
-	// Helper to install with config
-	installWithConfig := func(method installer.InstallMethod, config InstallConfig) error {
+	// Helper to install with config, returns the container settings used
+	installWithConfig := func(method installer.InstallMethod, config InstallConfig) (*installer.ContainerSpec, error) {
+		if !opts.IsZero() && (method != installer.MethodDocker || config.DockerCompose != "" || len(config.DockerPorts) == 0) {
+			return nil, fmt.Errorf("container options only apply to tools installed as a docker container")
+		}
+		if t.Instance != "" && (method != installer.MethodDocker || (config.DockerCompose == "" && len(config.DockerPorts) == 0)) {
+			return nil, fmt.Errorf("instances only apply to tools installed as a docker container or compose app")
+		}
+
 		// Special handling for Docker
 		if method == installer.MethodDocker {
 			dockerInst := installer.NewDockerInstaller()
 			if !dockerInst.IsAvailable() {
-				return fmt.Errorf("docker is not available on this system")
+				return nil, fmt.Errorf("docker is not available on this system")
 			}
 
 			// If docker-compose repo is specified, clone and use docker-compose
 			if config.DockerCompose != "" {
-				return dockerInst.InstallWithCompose(config.DockerCompose, t.Name)
+				return nil, dockerInst.InstallWithCompose(config.composeSource(), stateName(t.ID()), t.composeProject(), config.ComposeSecrets, config.SecretRefs, opts.ChoosePort)
 			}
 
 			// If ports are configured, use InstallAndRun
 			if len(config.DockerPorts) > 0 {
-				containerName := config.DockerName
-				if containerName == "" {
-					containerName = t.Name
+				var prev *installer.ContainerSpec
+				if r := t.receipt(); r != nil {
+					prev = r.Container
+				}
+				spec, err := t.containerSpec(config, prev, opts)
+				if err != nil {
+					return nil, err
+				}
+				t.wireBackends(&spec, opts.BackendEnv)
+				// A renamed container replaces the previous one, which
+				// may hold the same ports. It is kept until the new one runs.
+				var replaces string
+				if prev != nil && prev.Name != spec.Name {
+					replaces = prev.Name
+				}
+				if err := installer.ResolvePortsAvoiding(&spec, replaces, t.siblingPorts(), opts.ChoosePort); err != nil {
+					return nil, err
 				}
-				return dockerInst.InstallAndRun(config.Package, containerName, config.DockerPorts, config.DockerEnv, config.DockerVolumes)
+				if err := dockerInst.InstallAndRun(spec, replaces); err != nil {
+					return nil, err
+				}
+				return &spec, nil
 			}
 
 			// Otherwise just pull
-			return dockerInst.Install(config.Package, config.Args...)
+			return nil, dockerInst.Install(config.Package, config.Args...)
 		}
 
 		// Special handling for Download (desktop apps)
@@ -565,12 +692,7 @@ func (t *Tool) Install(preferredMethod installer.InstallMethod) error {
 			inst := installer.NewDownloadInstaller()
 
 			// Get platform-specific download URL
-			downloadURL := ""
-			if config.DownloadURLs != nil {
-				if url, ok := config.DownloadURLs[p.OS]; ok {
-					downloadURL = url
-				}
-			}
+			downloadURL := selectDownloadURL(config.DownloadURLs, p)
 
 			// Determine file type from config or URL
 			fileType := config.FileType
@@ -578,29 +700,34 @@ func (t *Tool) Install(preferredMethod installer.InstallMethod) error {
 				fileType = guessFileType(downloadURL, p.OS)
 			}
 
-			return inst.Install(config.Package, t.Name, downloadURL, fileType)
+			return nil, inst.Install(config.Package, t.Name, downloadURL, fileType)
 		}
 
 		// Standard installation
 		inst, err := installer.GetInstaller(method)
+		if err != nil {
+			return nil, err
+		}
+		return nil, inst.Install(config.Package, config.Args...)
+	}
+
+	install := func(method installer.InstallMethod, config InstallConfig) error {
+		spec, err := installWithConfig(method, config)
 		if err != nil {
 			return err
 		}
-		return inst.Install(config.Package, config.Args...)
+		t.recordInstall(method, spec)
+		return nil
 	}
 
 	// Check platform overrides first
-	if t.PlatformOverrides != nil {
-		if overrides, ok := t.PlatformOverrides[p.OS]; ok {
-			if config, ok := overrides[preferredMethod]; ok {
-				return installWithConfig(preferredMethod, config)
-			}
-		}
+	if config, ok := t.platformConfig(p, preferredMethod); ok {
+		return install(preferredMethod, config)
 	}
 
 	// Try preferred method
 	if config, ok := t.InstallMethods[preferredMethod]; ok {
-		return installWithConfig(preferredMethod, config)
+		return install(preferredMethod, config)
 	}
 
 	// Fallback to any available method
@@ -608,7 +735,7 @@ func (t *Tool) Install(preferredMethod installer.InstallMethod) error {
 		_, err := installer.GetInstaller(method)
 		if err == nil {
 			fmt.Printf("Using %s to install %s...\n", method, t.Name)
-			return installWithConfig(method, config)
+			return install(method, config)
 		}
 	}
 
@@ -620,18 +747,19 @@ func (t *Tool) GetAvailableMethods() []installer.InstallMethod {
 	var methods []installer.InstallMethod
 	var preferredMethod installer.InstallMethod
 
-	// Check platform-specific preferred method
-	if t.PlatformOverrides != nil {
-		if overrides, ok := t.PlatformOverrides[p.OS]; ok {
-			for method := range overrides {
-				preferredMethod = method
-				break
-			}
-		}
-	}
+	// Matching platform overrides may add methods that are not part of
+	// the generic InstallMethods (e.g. apt on Linux)
+	overrides := t.overrideMethods(p)
 
 	// Collect available methods
+	candidates := make(map[installer.InstallMethod]bool)
 	for method := range t.InstallMethods {
+		candidates[method] = true
+	}
+	for method := range overrides {
+		candidates[method] = true
+	}
+	for method := range candidates {
 		inst, err := installer.GetInstaller(method)
 		if err == nil && inst.IsAvailable() {
 			methods = append(methods, method)
@@ -653,6 +781,19 @@ func (t *Tool) GetAvailableMethods() []installer.InstallMethod {
 		installer.MethodDownload: 8, // Fallback for direct downloads
 	}
 
+	// Platform-specific preferred method: the highest priority method of
+	// the most specific override that has one available
+	for _, method := range methods {
+		rank, ok := overrides[method]
+		if !ok {
+			continue
+		}
+		if preferredMethod == "" || rank < overrides[preferredMethod] ||
+			(rank == overrides[preferredMethod] && methodPriority[method] < methodPriority[preferredMethod]) {
+			preferredMethod = method
+		}
+	}
+
 	sort.Slice(methods, func(i, j int) bool {
 		// Preferred method always first
 		if methods[i] == preferredMethod {
@@ -676,6 +817,63 @@ func (t *Tool) GetAvailableMethods() []installer.InstallMethod {
 	return methods
 }
 
+// overrideMethods returns the methods of the platform overrides matching
+// p, each with the rank of the most specific selector defining it (0 for
+// the most specific), the way platformConfig resolves them
+func (t *Tool) overrideMethods(p *platform.Platform) map[installer.InstallMethod]int {
+	type match struct {
+		selector string
+		score    int
+	}
+	var matches []match
+	for sel := range t.PlatformOverrides {
+		if score, ok := p.Match(sel); ok {
+			matches = append(matches, match{sel, score})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].selector < matches[j].selector
+	})
+
+	methods := make(map[installer.InstallMethod]int)
+	for rank, m := range matches {
+		for method := range t.PlatformOverrides[m.selector] {
+			if _, ok := methods[method]; !ok {
+				methods[method] = rank
+			}
+		}
+	}
+	return methods
+}
+
+// platformConfig returns the config for method from the most specific
+// platform override that defines it
+func (t *Tool) platformConfig(p *platform.Platform, method installer.InstallMethod) (InstallConfig, bool) {
+	var selectors []string
+	for sel, overrides := range t.PlatformOverrides {
+		if _, ok := overrides[method]; ok {
+			selectors = append(selectors, sel)
+		}
+	}
+	best := p.BestMatch(selectors)
+	if best == "" {
+		return InstallConfig{}, false
+	}
+	return t.PlatformOverrides[best][method], true
+}
+
+// selectDownloadURL picks the download URL of the most specific matching selector
+func selectDownloadURL(urls map[string]string, p *platform.Platform) string {
+	selectors := make([]string, 0, len(urls))
+	for sel := range urls {
+		selectors = append(selectors, sel)
+	}
+	return urls[p.BestMatch(selectors)]
+}
+
 // New tool registrations
 
 func registerGPTEngineer() {
@@ -711,8 +909,14 @@ func registerFabric() {
 		Category:    CategoryUtility,
 		Website:     "https://github.com/danielmiessler/fabric",
 		Command:     "fabric",
+		DataPaths:   []string{"~/.config/fabric"},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
-			installer.MethodGo:  {Package: "github.com/danielmiessler/fabric"},
+			// Go 1.21+ downloads the newer toolchain fabric's go.mod asks for
+			installer.MethodGo: {
+				Package:  "github.com/danielmiessler/fabric",
+				Requires: []string{"go"},
+				Runtimes: map[string]string{"go": ">=1.21"},
+			},
 			installer.MethodPip: {Package: "fabric-ai"},
 		},
 	})
@@ -725,6 +929,7 @@ func registerShellGPT() {
 		Category:    CategoryUtility,
 		Website:     "https://github.com/TheR1D/shell_gpt",
 		Command:     "sgpt",
+		DataPaths:   []string{"~/.config/shell_gpt"},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodPip: {Package: "shell-gpt"},
 		},
@@ -738,6 +943,7 @@ func registerMods() {
 		Category:    CategoryUtility,
 		Website:     "https://github.com/charmbracelet/mods",
 		Command:     "mods",
+		DataPaths:   []string{"~/.config/mods", "~/Library/Application Support/mods"},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodBrew: {Package: "mods"},
 			installer.MethodGo:   {Package: "github.com/charmbracelet/mods"},
@@ -793,6 +999,8 @@ func registerTabby() {
 		Category:    CategoryCoding,
 		Website:     "https://tabby.tabbyml.com",
 		Command:     "tabby",
+		Service:     &ServiceConfig{Command: []string{"tabby", "serve", "--model", "StarCoder-1B", "--port", "8080"}, Port: 8080},
+		Ready:       &ReadyCheck{HTTP: "/v1/health", Timeout: 10 * time.Minute}, // downloads the model on first start
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodBrew: {Package: "tabbyml/tabby/tabby"},
 		},
@@ -806,8 +1014,16 @@ func registerLocalAI() {
 		Category:    CategoryLLM,
 		Website:     "https://localai.io",
 		Command:     "local-ai",
+		Resources:   Resources{MemoryGB: 8, DiskGB: 20, CPUCores: 4, DownloadGB: 6},
+		Ready:       &ReadyCheck{HTTP: "/readyz"},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
-			installer.MethodDocker: {Package: "localai/localai:latest"},
+			installer.MethodDocker: {
+				Package:       "localai/localai:latest",
+				DockerName:    "localai",
+				DockerPorts:   []string{"8080:8080"},
+				DockerEnv:     map[string]string{"MODELS_PATH": "/models"},
+				DockerVolumes: []string{"localai-models:/models"},
+			},
 		},
 	})
 }
@@ -819,8 +1035,17 @@ func registerAnythingLLM() {
 		Category:    CategoryUI,
 		Website:     "https://anythingllm.com",
 		Command:     "",
+		Ready:       &ReadyCheck{HTTP: "/api/ping"},
+		Recommends:  []string{"ollama"},
+		BackendEnv:  map[string]string{"OLLAMA_BASE_PATH": "{ollama}"},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
-			installer.MethodDocker: {Package: "mintplexlabs/anythingllm"},
+			installer.MethodDocker: {
+				Package:       "mintplexlabs/anythingllm",
+				DockerName:    "anythingllm",
+				DockerPorts:   []string{"3001:3001"},
+				DockerEnv:     map[string]string{"STORAGE_DIR": "/app/server/storage"},
+				DockerVolumes: []string{"anythingllm-storage:/app/server/storage"},
+			},
 		},
 	})
 }
@@ -860,8 +1085,9 @@ func registerMSDD() {
 			installer.MethodDownload: {
 				Package: "https://msty.app",
 				DownloadURLs: map[string]string{
-					"darwin":  "https://assets.msty.app/Msty_arm64.dmg",
-					"windows": "https://assets.msty.app/Msty_x64.exe",
+					"darwin":       "https://assets.msty.app/Msty_arm64.dmg",
+					"darwin/amd64": "https://assets.msty.app/Msty_x64.dmg",
+					"windows":      "https://assets.msty.app/Msty_x64.exe",
 				},
 			},
 		},
@@ -877,10 +1103,17 @@ func registerOneAPI() {
 		Category:    CategoryPlatform,
 		Website:     "https://github.com/songquanpeng/one-api",
 		Command:     "",
+		Ready:       &ReadyCheck{HTTP: "/api/status", Port: 3000},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodDocker: {
 				Package:       "justsong/one-api",
 				DockerCompose: "https://github.com/songquanpeng/one-api",
+				ComposeRef:    "v0.6.10",
+				// SQLite instead of MySQL
+				ExportSpec: &InstallConfig{
+					DockerPorts:   []string{"3000:3000"},
+					DockerVolumes: []string{"one-api-data:/data"},
+				},
 			},
 		},
 	})
@@ -893,10 +1126,27 @@ func registerDify() {
 		Category:    CategoryPlatform,
 		Website:     "https://dify.ai",
 		Command:     "",
+		Resources:   Resources{MemoryGB: 4, DiskGB: 20, CPUCores: 2, DownloadGB: 5},
+		Ready:       &ReadyCheck{HTTP: "/", Timeout: 5 * time.Minute},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodDocker: {
 				Package:       "langgenius/dify-web",
 				DockerCompose: "https://github.com/langgenius/dify",
+				ComposeRef:    "1.4.0",
+				ComposePath:   "docker",
+				ComposeSecrets: map[string]int{
+					"SECRET_KEY":                42,
+					"DB_PASSWORD":               24,
+					"REDIS_PASSWORD":            24,
+					"CODE_EXECUTION_API_KEY":    24,
+					"PLUGIN_DAEMON_KEY":         32,
+					"PLUGIN_DIFY_INNER_API_KEY": 32,
+				},
+				SecretRefs: map[string]string{
+					"CELERY_BROKER_URL": "redis://:{REDIS_PASSWORD}@redis:6379/1",
+					"SANDBOX_API_KEY":   "{CODE_EXECUTION_API_KEY}", // the sandbox checks the api's key
+				},
+				Runtimes: map[string]string{"compose": ">=2"},
 			},
 		},
 	})
@@ -909,10 +1159,15 @@ func registerFastGPT() {
 		Category:    CategoryPlatform,
 		Website:     "https://fastgpt.io",
 		Command:     "",
+		Ready:       &ReadyCheck{HTTP: "/", Port: 3000, Timeout: 5 * time.Minute},
+		Resources:   Resources{MemoryGB: 4, DiskGB: 20, CPUCores: 2, DownloadGB: 3},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodDocker: {
 				Package:       "ghcr.io/labring/fastgpt",
 				DockerCompose: "https://github.com/labring/FastGPT",
+				ComposeRef:    "v4.9.0",
+				ComposePath:   "deploy/docker",
+				ComposeFile:   "docker-compose-pgvector.yml",
 			},
 		},
 	})
@@ -925,6 +1180,8 @@ func registerLobeChat() {
 		Category:    CategoryUI,
 		Website:     "https://lobehub.com",
 		Command:     "",
+		Ready:       &ReadyCheck{HTTP: "/"},
+		BackendEnv:  map[string]string{"OLLAMA_PROXY_URL": "{ollama}"},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodDocker: {
 				Package:     "lobehub/lobe-chat",
@@ -942,6 +1199,7 @@ func registerChatGPTNextWeb() {
 		Category:    CategoryUI,
 		Website:     "https://github.com/ChatGPTNextWeb/ChatGPT-Next-Web",
 		Command:     "",
+		Ready:       &ReadyCheck{HTTP: "/"},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodDocker: {
 				Package:     "yidadaa/chatgpt-next-web",
@@ -959,8 +1217,11 @@ func registerFlowise() {
 		Category:    CategoryPlatform,
 		Website:     "https://flowiseai.com",
 		Command:     "flowise",
+		Service:     &ServiceConfig{Command: []string{"flowise", "start"}, Port: 3000},
+		WebURL:      "http://localhost:{port}",
+		Ready:       &ReadyCheck{HTTP: "/api/v1/ping", Port: 3000},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
-			installer.MethodNpm: {Package: "flowise"},
+			installer.MethodNpm: {Package: "flowise", Runtimes: map[string]string{"node": ">=18.15"}},
 			installer.MethodDocker: {
 				Package:     "flowiseai/flowise",
 				DockerName:  "flowise",
@@ -980,6 +1241,7 @@ func registerLangflow() {
 		Category:    CategoryPlatform,
 		Website:     "https://langflow.org",
 		Command:     "langflow",
+		Ready:       &ReadyCheck{HTTP: "/health", Port: 7860},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodPip: {Package: "langflow"},
 			installer.MethodDocker: {
@@ -1002,6 +1264,7 @@ func registerQuivr() {
 			installer.MethodDocker: {
 				Package:       "quivr/quivr-backend",
 				DockerCompose: "https://github.com/QuivrHQ/quivr",
+				ComposeRef:    "v0.0.204",
 			},
 		},
 	})
@@ -1018,6 +1281,7 @@ func registerPrivateGPT() {
 			installer.MethodDocker: {
 				Package:       "zylonai/private-gpt",
 				DockerCompose: "https://github.com/zylon-ai/private-gpt",
+				ComposeRef:    "v0.6.2",
 			},
 		},
 	})
@@ -1030,10 +1294,19 @@ func registerLibreChat() {
 		Category:    CategoryUI,
 		Website:     "https://librechat.ai",
 		Command:     "",
+		Ready:       &ReadyCheck{HTTP: "/"},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodDocker: {
 				Package:       "ghcr.io/danny-avila/librechat",
 				DockerCompose: "https://github.com/danny-avila/LibreChat",
+				ComposeRef:    "v0.7.8",
+				ComposeSecrets: map[string]int{
+					"CREDS_KEY":          32, // AES-256 key, 64 hex chars
+					"CREDS_IV":           16, // AES IV, 32 hex chars
+					"JWT_SECRET":         32,
+					"JWT_REFRESH_SECRET": 32,
+					"MEILI_MASTER_KEY":   16,
+				},
 			},
 		},
 	})
@@ -1048,8 +1321,9 @@ func registerVLLM() {
 		Category:    CategoryInfra,
 		Website:     "https://vllm.ai",
 		Command:     "vllm",
+		Resources:   Resources{MemoryGB: 16, DiskGB: 30, CPUCores: 4, DownloadGB: 10},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
-			installer.MethodPip:    {Package: "vllm"},
+			installer.MethodPip:    {Package: "vllm", Runtimes: map[string]string{"python": ">=3.9,<3.13"}},
 			installer.MethodDocker: {Package: "vllm/vllm-openai"},
 		},
 	})
@@ -1062,8 +1336,14 @@ func registerTextGenWebUI() {
 		Category:    CategoryInfra,
 		Website:     "https://github.com/oobabooga/text-generation-webui",
 		Command:     "",
+		Ready:       &ReadyCheck{HTTP: "/", Timeout: 5 * time.Minute},
+		Resources:   Resources{MemoryGB: 8, DiskGB: 30, CPUCores: 4, DownloadGB: 8},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
-			installer.MethodDocker: {Package: "atinoda/text-generation-webui"},
+			installer.MethodDocker: {
+				Package:     "atinoda/text-generation-webui",
+				DockerName:  "text-gen-webui",
+				DockerPorts: []string{"7860:7860"},
+			},
 		},
 	})
 }
@@ -1075,8 +1355,15 @@ func registerComfyUI() {
 		Category:    CategoryInfra,
 		Website:     "https://github.com/comfyanonymous/ComfyUI",
 		Command:     "",
+		Ready:       &ReadyCheck{HTTP: "/system_stats", Timeout: 10 * time.Minute}, // sets up ComfyUI on first start
+		Resources:   Resources{MemoryGB: 8, DiskGB: 30, CPUCores: 4, DownloadGB: 6},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
-			installer.MethodDocker: {Package: "yanwk/comfyui-boot"},
+			installer.MethodDocker: {
+				Package:       "yanwk/comfyui-boot",
+				DockerName:    "comfyui",
+				DockerPorts:   []string{"8188:8188"},
+				DockerVolumes: []string{"comfyui-data:/root"},
+			},
 		},
 	})
 }
@@ -1088,8 +1375,14 @@ func registerSDWebUI() {
 		Category:    CategoryInfra,
 		Website:     "https://github.com/AUTOMATIC1111/stable-diffusion-webui",
 		Command:     "",
+		Ready:       &ReadyCheck{HTTP: "/", Timeout: 10 * time.Minute}, // installs its dependencies on first start
+		Resources:   Resources{MemoryGB: 8, DiskGB: 30, CPUCores: 4, DownloadGB: 8},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
-			installer.MethodDocker: {Package: "universonic/stable-diffusion-webui"},
+			installer.MethodDocker: {
+				Package:     "universonic/stable-diffusion-webui",
+				DockerName:  "sd-webui",
+				DockerPorts: []string{"8080:8080"},
+			},
 		},
 	})
 }
@@ -1101,6 +1394,12 @@ func registerKoboldCpp() {
 		Category:    CategoryInfra,
 		Website:     "https://github.com/LostRuins/koboldcpp",
 		Command:     "",
+		Service: &ServiceConfig{
+			Command:  []string{"koboldcpp", "--host", "127.0.0.1", "--port", "5001"},
+			Port:     5001,
+			HostHint: "--host 0.0.0.0",
+			ArgsHint: "--model <model.gguf>",
+		},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodBrew: {Package: "koboldcpp"},
 		},
@@ -1114,6 +1413,13 @@ func registerLlamaCpp() {
 		Category:    CategoryInfra,
 		Website:     "https://github.com/ggerganov/llama.cpp",
 		Command:     "llama-cli",
+		Service: &ServiceConfig{
+			Command:  []string{"llama-server", "--host", "127.0.0.1", "--port", "8080"},
+			Port:     8080,
+			HostHint: "--host 0.0.0.0",
+			ArgsHint: "-m <model.gguf>",
+		},
+		Ready: &ReadyCheck{HTTP: "/health"},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodBrew: {Package: "llama.cpp"},
 		},
@@ -1168,6 +1474,7 @@ func registerNewAPI() {
 		Category:    CategoryPlatform,
 		Website:     "https://github.com/Calcium-Ion/new-api",
 		Command:     "",
+		Ready:       &ReadyCheck{HTTP: "/api/status"},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodDocker: {
 				Package:     "calciumion/new-api",
@@ -1188,10 +1495,13 @@ func registerMaxKB() {
 		Category:    CategoryPlatform,
 		Website:     "https://github.com/1Panel-dev/MaxKB",
 		Command:     "",
+		Ready:       &ReadyCheck{HTTP: "/", Port: 8080, Timeout: 5 * time.Minute},
+		Resources:   Resources{MemoryGB: 4, DiskGB: 20, CPUCores: 2, DownloadGB: 3},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodDocker: {
 				Package:       "1panel/maxkb",
 				DockerCompose: "https://github.com/1Panel-dev/MaxKB",
+				ComposeRef:    "v1.9.0",
 			},
 		},
 	})
@@ -1204,10 +1514,14 @@ func registerRAGFlow() {
 		Category:    CategoryPlatform,
 		Website:     "https://ragflow.io",
 		Command:     "",
+		Resources:   Resources{MemoryGB: 16, DiskGB: 50, CPUCores: 4, DownloadGB: 9},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodDocker: {
 				Package:       "infiniflow/ragflow",
 				DockerCompose: "https://github.com/infiniflow/ragflow",
+				ComposeRef:    "v0.19.0",
+				ComposePath:   "docker",
+				Runtimes:      map[string]string{"compose": ">=2"},
 			},
 		},
 	})
@@ -1220,11 +1534,13 @@ func registerDBGPT() {
 		Category:    CategoryPlatform,
 		Website:     "https://github.com/eosphoros-ai/DB-GPT",
 		Command:     "dbgpt",
+		Resources:   Resources{MemoryGB: 8, DiskGB: 30, CPUCores: 4, DownloadGB: 8},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodPip: {Package: "dbgpt"},
 			installer.MethodDocker: {
 				Package:       "eosphorosai/dbgpt",
 				DockerCompose: "https://github.com/eosphoros-ai/DB-GPT",
+				ComposeRef:    "v0.7.0",
 			},
 		},
 	})
@@ -1263,6 +1579,9 @@ func registerXinference() {
 		Category:    CategoryInfra,
 		Website:     "https://github.com/xorbitsai/inference",
 		Command:     "xinference",
+		Resources:   Resources{MemoryGB: 8, DiskGB: 30, CPUCores: 4, DownloadGB: 10},
+		Service:     &ServiceConfig{Command: []string{"xinference-local", "--host", "127.0.0.1", "--port", "9997"}, Port: 9997, HostHint: "--host 0.0.0.0"},
+		WebURL:      "http://localhost:{port}",
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodPip:    {Package: "xinference"},
 			installer.MethodDocker: {Package: "xprobe/xinference"},
@@ -1277,6 +1596,7 @@ func registerSGLang() {
 		Category:    CategoryInfra,
 		Website:     "https://github.com/sgl-project/sglang",
 		Command:     "",
+		Resources:   Resources{MemoryGB: 16, DiskGB: 40, CPUCores: 4, DownloadGB: 12},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodPip:    {Package: "sglang"},
 			installer.MethodDocker: {Package: "lmsysorg/sglang"},
@@ -1323,6 +1643,26 @@ func registerNode() {
 	})
 }
 
+func registerGo() {
+	Register(&Tool{
+		Name:        "go",
+		Description: "The Go programming language toolchain",
+		Category:    CategoryUtility,
+		Website:     "https://go.dev",
+		Command:     "go",
+		InstallMethods: map[installer.InstallMethod]InstallConfig{
+			installer.MethodBrew:  {Package: "go"},
+			installer.MethodChoco: {Package: "golang"},
+			installer.MethodScoop: {Package: "go"},
+		},
+		PlatformOverrides: map[string]map[installer.InstallMethod]InstallConfig{
+			"linux:debian": {
+				installer.MethodApt: {Package: "golang-go"},
+			},
+		},
+	})
+}
+
 func registerDocker() {
 	Register(&Tool{
 		Name:        "docker",
@@ -1330,6 +1670,8 @@ func registerDocker() {
 		Category:    CategoryUtility,
 		Website:     "https://www.docker.com",
 		Command:     "docker",
+		Provides:    []string{"docker-engine", "compose-v2"},
+		Conflicts:   []string{"orbstack"},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodBrew:  {Package: "docker", Args: []string{"--cask"}},
 			installer.MethodChoco: {Package: "docker-desktop"},
@@ -1353,6 +1695,9 @@ func registerDockerCompose() {
 		Category:    CategoryUtility,
 		Website:     "https://docs.docker.com/compose",
 		Command:     "docker-compose",
+		// Standalone client for engines without the compose v2 plugin
+		// (podman, colima), so it doesn't require one of ours
+		Conflicts: []string{"compose-v2"},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodBrew:  {Package: "docker-compose"},
 			installer.MethodPip:   {Package: "docker-compose"},
@@ -1407,9 +1752,11 @@ func registerCherryStudio() {
 				Package: "https://github.com/CherryHQ/cherry-studio/releases",
 				// Official repository: github.com/CherryHQ/cherry-studio
 				DownloadURLs: map[string]string{
-					"darwin":  "https://github.com/CherryHQ/cherry-studio/releases/download/v1.7.13/Cherry-Studio-1.7.13-arm64.dmg",
-					"linux":   "https://github.com/CherryHQ/cherry-studio/releases/download/v1.7.13/Cherry-Studio_1.7.13_amd64.deb",
-					"windows": "https://github.com/CherryHQ/cherry-studio/releases/download/v1.7.13/Cherry-Studio-1.7.13-x64-setup.exe",
+					"darwin":       "https://github.com/CherryHQ/cherry-studio/releases/download/v1.7.13/Cherry-Studio-1.7.13-arm64.dmg",
+					"darwin/amd64": "https://github.com/CherryHQ/cherry-studio/releases/download/v1.7.13/Cherry-Studio-1.7.13-x64.dmg",
+					"linux":        "https://github.com/CherryHQ/cherry-studio/releases/download/v1.7.13/Cherry-Studio_1.7.13_amd64.deb",
+					"linux/arm64":  "https://github.com/CherryHQ/cherry-studio/releases/download/v1.7.13/Cherry-Studio_1.7.13_arm64.deb",
+					"windows":      "https://github.com/CherryHQ/cherry-studio/releases/download/v1.7.13/Cherry-Studio-1.7.13-x64-setup.exe",
 				},
 			},
 		},
@@ -1465,8 +1812,9 @@ func registerCodexCLI() {
 		Category:    CategoryCoding,
 		Website:     "https://github.com/openai/codex",
 		Command:     "codex",
+		DataPaths:   []string{"~/.codex"},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
-			installer.MethodNpm:  {Package: "@openai/codex"},
+			installer.MethodNpm:  {Package: "@openai/codex", Runtimes: map[string]string{"node": ">=18"}},
 			installer.MethodBrew: {Package: "codex", Args: []string{"--cask"}},
 		},
 	})
@@ -1479,8 +1827,9 @@ func registerGeminiCLI() {
 		Category:    CategoryUtility,
 		Website:     "https://github.com/google-gemini/gemini-cli",
 		Command:     "gemini",
+		DataPaths:   []string{"~/.gemini"},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
-			installer.MethodNpm: {Package: "@google/gemini-cli"},
+			installer.MethodNpm: {Package: "@google/gemini-cli", Runtimes: map[string]string{"node": ">=20"}},
 		},
 	})
 }
@@ -1492,6 +1841,7 @@ func registerAIChat() {
 		Category:    CategoryUtility,
 		Website:     "https://github.com/sigoden/aichat",
 		Command:     "aichat",
+		DataPaths:   []string{"~/.config/aichat", "~/Library/Application Support/aichat"},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodBrew: {Package: "aichat"},
 		},
@@ -1505,6 +1855,7 @@ func registerGoingChat() {
 		Category:    CategoryCoding,
 		Website:     "https://github.com/ErikBjworken/gptme",
 		Command:     "gptme",
+		DataPaths:   []string{"~/.config/gptme", "~/.local/share/gptme"},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodPip: {Package: "gptme-python"},
 		},
@@ -1518,6 +1869,7 @@ func registerOpenInterpreter() {
 		Category:    CategoryCoding,
 		Website:     "https://openinterpreter.com",
 		Command:     "interpreter",
+		DataPaths:   []string{"~/.config/open-interpreter", "~/Library/Application Support/open-interpreter"},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodPip: {Package: "open-interpreter"},
 		},
@@ -1672,9 +2024,11 @@ func registerVSCode() {
 			installer.MethodDownload: {
 				Package: "https://code.visualstudio.com/download",
 				DownloadURLs: map[string]string{
-					"darwin":  "https://code.visualstudio.com/sha/download?build=stable&os=darwin-universal",
-					"linux":   "https://code.visualstudio.com/sha/download?build=stable&os=linux-deb-x64",
-					"windows": "https://code.visualstudio.com/sha/download?build=stable&os=win32-x64-user",
+					"darwin":        "https://code.visualstudio.com/sha/download?build=stable&os=darwin-universal",
+					"linux":         "https://code.visualstudio.com/sha/download?build=stable&os=linux-deb-x64",
+					"linux/arm64":   "https://code.visualstudio.com/sha/download?build=stable&os=linux-deb-arm64",
+					"windows":       "https://code.visualstudio.com/sha/download?build=stable&os=win32-x64-user",
+					"windows/arm64": "https://code.visualstudio.com/sha/download?build=stable&os=win32-arm64-user",
 				},
 			},
 		},
@@ -1885,6 +2239,7 @@ func registerOrbStack() {
 		Website:     "https://orbstack.dev",
 		Command:     "orb",
 		AppName:     "OrbStack.app",
+		Provides:    []string{"docker-engine", "compose-v2"},
 		InstallMethods: map[installer.InstallMethod]InstallConfig{
 			installer.MethodBrew: {Package: "orbstack", Args: []string{"--cask"}},
 			installer.MethodDownload: {
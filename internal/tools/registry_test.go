@@ -1,9 +1,11 @@
 package tools
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/getoai/getoai-cli/internal/installer"
+	"github.com/getoai/getoai-cli/internal/platform"
 )
 
 func TestAllToolsHaveValidConfiguration(t *testing.T) {
@@ -246,3 +248,321 @@ func TestDownloadURLsFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectDownloadURL(t *testing.T) {
+	urls := map[string]string{
+		"darwin":       "mac-arm64.dmg",
+		"darwin/amd64": "mac-x64.dmg",
+		"linux":        "linux-x64.deb",
+		"linux/arm64":  "linux-arm64.deb",
+	}
+
+	tests := []struct {
+		name     string
+		platform *platform.Platform
+		want     string
+	}{
+		{name: "Apple Silicon", platform: &platform.Platform{OS: "darwin", Arch: "arm64"}, want: "mac-arm64.dmg"},
+		{name: "Intel Mac", platform: &platform.Platform{OS: "darwin", Arch: "amd64"}, want: "mac-x64.dmg"},
+		{name: "Linux amd64", platform: &platform.Platform{OS: "linux", Arch: "amd64"}, want: "linux-x64.deb"},
+		{name: "Linux arm64", platform: &platform.Platform{OS: "linux", Arch: "arm64"}, want: "linux-arm64.deb"},
+		{name: "Windows", platform: &platform.Platform{OS: "windows", Arch: "amd64"}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := selectDownloadURL(urls, tt.platform); got != tt.want {
+				t.Errorf("selectDownloadURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlatformConfigMostSpecificWins(t *testing.T) {
+	tool := &Tool{
+		Name: "example",
+		PlatformOverrides: map[string]map[installer.InstallMethod]InstallConfig{
+			"linux": {
+				installer.MethodScript: {Package: "generic.sh"},
+				installer.MethodApt:    {Package: "example"},
+			},
+			"linux/arm64": {
+				installer.MethodScript: {Package: "arm64.sh"},
+			},
+		},
+	}
+	arm := &platform.Platform{OS: "linux", Arch: "arm64"}
+
+	if config, ok := tool.platformConfig(arm, installer.MethodScript); !ok || config.Package != "arm64.sh" {
+		t.Errorf("script override = %q, want arm64.sh", config.Package)
+	}
+	// Methods missing from the specific override fall back to the generic one
+	if config, ok := tool.platformConfig(arm, installer.MethodApt); !ok || config.Package != "example" {
+		t.Errorf("apt override = %q, want example", config.Package)
+	}
+	if _, ok := tool.platformConfig(&platform.Platform{OS: "darwin", Arch: "arm64"}, installer.MethodScript); ok {
+		t.Error("darwin should not match linux overrides")
+	}
+}
+
+func TestOverrideMethodsIncludeLessSpecific(t *testing.T) {
+	tool := &Tool{
+		Name: "example",
+		PlatformOverrides: map[string]map[installer.InstallMethod]InstallConfig{
+			"linux":        {installer.MethodScript: {Package: "generic.sh"}},
+			"linux:debian": {installer.MethodApt: {Package: "example"}},
+			"darwin":       {installer.MethodBrew: {Package: "example"}},
+		},
+	}
+	debian := &platform.Platform{OS: "linux", Arch: "amd64", Distro: "debian"}
+
+	got := tool.overrideMethods(debian)
+	want := map[installer.InstallMethod]int{installer.MethodApt: 0, installer.MethodScript: 1}
+	if len(got) != len(want) {
+		t.Fatalf("overrideMethods() = %v, want %v", got, want)
+	}
+	for method, rank := range want {
+		if r, ok := got[method]; !ok || r != rank {
+			t.Errorf("overrideMethods()[%s] = %d, %v, want %d", method, r, ok, rank)
+		}
+	}
+}
+
+func TestPlatformSelectorsAreValid(t *testing.T) {
+	validOS := map[string]bool{"darwin": true, "linux": true, "windows": true, "wsl": true}
+
+	for _, tool := range List() {
+		var selectors []string
+		for sel := range tool.PlatformOverrides {
+			selectors = append(selectors, sel)
+		}
+		for _, config := range tool.InstallMethods {
+			for sel := range config.DownloadURLs {
+				selectors = append(selectors, sel)
+			}
+		}
+		for _, sel := range selectors {
+			osName := strings.FieldsFunc(sel, func(r rune) bool { return r == '/' || r == ':' })[0]
+			if !validOS[osName] {
+				t.Errorf("Tool %s has invalid platform selector %q", tool.Name, sel)
+			}
+		}
+	}
+}
+
+func TestRuntimeRequirementsAreValid(t *testing.T) {
+	validRuntimes := make(map[string]bool)
+	for _, name := range platform.RuntimeNames() {
+		validRuntimes[name] = true
+	}
+
+	for _, tool := range List() {
+		for method, config := range tool.InstallMethods {
+			for runtime, constraint := range config.Runtimes {
+				if !validRuntimes[runtime] {
+					t.Errorf("Tool %s %s method requires unknown runtime %q", tool.Name, method, runtime)
+				}
+				if constraint == "" {
+					t.Errorf("Tool %s %s method has empty %s constraint", tool.Name, method, runtime)
+				}
+			}
+		}
+	}
+}
+
+func TestComposeAppsArePinned(t *testing.T) {
+	for _, tool := range List() {
+		for method, config := range tool.InstallMethods {
+			if config.DockerCompose != "" && config.ComposeRef == "" {
+				t.Errorf("Tool %s %s method installs %s from its default branch, set ComposeRef", tool.Name, method, config.DockerCompose)
+			}
+			if config.ComposeFile != "" && strings.Contains(config.ComposeFile, "/") {
+				t.Errorf("Tool %s %s method: ComposeFile %q must be a name in ComposePath", tool.Name, method, config.ComposeFile)
+			}
+		}
+	}
+}
+
+func TestWebUIsPublishPorts(t *testing.T) {
+	for _, name := range []string{"comfyui", "sd-webui", "text-gen-webui", "flowise"} {
+		tool, _ := Get(name)
+		config := tool.InstallMethods[installer.MethodDocker]
+		if config.DockerName == "" || len(config.DockerPorts) == 0 {
+			t.Errorf("%s docker method has no container name or port, 'getoai open' can't find its UI", name)
+		}
+		for method := range tool.InstallMethods {
+			if method != installer.MethodDocker && tool.Service == nil {
+				t.Errorf("%s %s method runs no service, 'getoai open' can't start or find its UI", name, method)
+			}
+		}
+	}
+}
+
+func TestContainerUIsHaveReadyProbe(t *testing.T) {
+	// A TCP probe passes while the app crash-loops behind docker-proxy
+	for _, tool := range List() {
+		config, ok := tool.InstallMethods[installer.MethodDocker]
+		if ok && len(config.DockerPorts) > 0 && tool.Ready == nil {
+			t.Errorf("Tool %s publishes %v but declares no Ready probe", tool.Name, config.DockerPorts)
+		}
+	}
+}
+
+func TestComposeAppsExportSpec(t *testing.T) {
+	for _, tool := range List() {
+		for method, config := range tool.InstallMethods {
+			if config.DockerCompose != "" && (len(config.DockerPorts) > 0 || len(config.DockerVolumes) > 0) {
+				t.Errorf("Tool %s %s method is a compose app with container ports or volumes, put them in ExportSpec", tool.Name, method)
+			}
+			if config.ExportSpec != nil && config.DockerCompose == "" {
+				t.Errorf("Tool %s %s method has an ExportSpec but runs as a single container", tool.Name, method)
+			}
+		}
+	}
+
+	t.Setenv("HOME", t.TempDir())
+	tool, _ := Get("one-api")
+	spec, err := tool.ExportSpec()
+	if err != nil {
+		t.Fatalf("ExportSpec() error = %v", err)
+	}
+	if spec.Image != "justsong/one-api" || len(spec.Ports) != 1 || spec.Ports[0] != "3000:3000" {
+		t.Errorf("ExportSpec() = %+v", spec)
+	}
+}
+
+func TestHeavyToolsDeclareResources(t *testing.T) {
+	heavyTools := []string{"dify", "ragflow", "vllm", "comfyui", "sd-webui"}
+
+	for _, name := range heavyTools {
+		tool, ok := Get(name)
+		if !ok {
+			t.Fatalf("Tool %s not found", name)
+		}
+		if tool.Resources.MemoryGB == 0 || tool.Resources.DiskGB == 0 {
+			t.Errorf("Tool %s should declare minimum memory and disk", name)
+		}
+		if tool.Resources.DownloadGB > tool.Resources.DiskGB {
+			t.Errorf("Tool %s download size exceeds its disk requirement", name)
+		}
+	}
+}
+
+func TestResourcesString(t *testing.T) {
+	r := Resources{MemoryGB: 16, DiskGB: 50, CPUCores: 4, DownloadGB: 9}
+	want := "16 GB RAM, 50 GB disk, 4 CPU cores (~9 GB download)"
+	if got := r.String(); got != want {
+		t.Errorf("Resources.String() = %q, want %q", got, want)
+	}
+	if got := (Resources{}).String(); got != "" {
+		t.Errorf("empty Resources.String() = %q, want empty", got)
+	}
+}
+
+func TestConflictsReferenceKnownNames(t *testing.T) {
+	known := make(map[string]bool)
+	for _, tool := range List() {
+		known[tool.Name] = true
+		for _, capability := range tool.Provides {
+			known[capability] = true
+		}
+	}
+
+	for _, tool := range List() {
+		for _, c := range tool.Conflicts {
+			if !known[c] {
+				t.Errorf("Tool %s conflicts with unknown tool or capability %q", tool.Name, c)
+			}
+		}
+	}
+}
+
+func TestServicesAreValid(t *testing.T) {
+	for _, tool := range List() {
+		if tool.Service == nil {
+			continue
+		}
+		if len(tool.Service.Command) == 0 {
+			t.Errorf("Tool %s declares a service without a command", tool.Name)
+		}
+		if tool.Service.Port <= 0 {
+			t.Errorf("Tool %s declares a service without a port", tool.Name)
+		}
+	}
+}
+
+func TestContainerName(t *testing.T) {
+	tests := []struct {
+		name string
+		tool *Tool
+		want string
+	}{
+		{
+			name: "Explicit name",
+			tool: &Tool{Name: "lobechat", InstallMethods: map[installer.InstallMethod]InstallConfig{
+				installer.MethodDocker: {DockerName: "lobe-chat", DockerPorts: []string{"3210:3210"}},
+			}},
+			want: "lobe-chat",
+		},
+		{
+			name: "Defaults to tool name",
+			tool: &Tool{Name: "web", InstallMethods: map[installer.InstallMethod]InstallConfig{
+				installer.MethodDocker: {DockerPorts: []string{"80:80"}},
+			}},
+			want: "web",
+		},
+		{
+			name: "Pull only",
+			tool: &Tool{Name: "img", InstallMethods: map[installer.InstallMethod]InstallConfig{
+				installer.MethodDocker: {Package: "example/img"},
+			}},
+			want: "",
+		},
+		{
+			name: "Compose app",
+			tool: &Tool{Name: "dify", InstallMethods: map[installer.InstallMethod]InstallConfig{
+				installer.MethodDocker: {DockerCompose: "https://github.com/langgenius/dify"},
+			}},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tool.ContainerName(); got != tt.want {
+				t.Errorf("ContainerName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadyChecksAreValid(t *testing.T) {
+	for _, tool := range List() {
+		if tool.Ready == nil {
+			continue
+		}
+		if tool.Service == nil && tool.ContainerName() == "" && !tool.IsDockerComposeInstall() {
+			t.Errorf("%s: Ready set on a tool that doesn't run as a service", tool.Name)
+		}
+		if tool.Ready.HTTP != "" && !strings.HasPrefix(tool.Ready.HTTP, "/") {
+			t.Errorf("%s: Ready.HTTP %q must be a path starting with /", tool.Name, tool.Ready.HTTP)
+		}
+		if tool.Ready.Port != 0 && tool.ContainerName() != "" && tool.containerHostPort(tool.Ready.Port) == 0 {
+			t.Errorf("%s: Ready.Port %d is not published by the container", tool.Name, tool.Ready.Port)
+		}
+	}
+}
+
+func TestWebURLsAreValid(t *testing.T) {
+	for _, tool := range List() {
+		if tool.WebURL == "" {
+			continue
+		}
+		if !strings.HasPrefix(tool.WebURL, "http://") && !strings.HasPrefix(tool.WebURL, "https://") {
+			t.Errorf("%s: WebURL %q must be an http(s) URL", tool.Name, tool.WebURL)
+		}
+		if tool.Service == nil && tool.ContainerName() == "" && !tool.IsDockerComposeInstall() {
+			t.Errorf("%s: WebURL set on a tool that doesn't run as a service", tool.Name)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package tools
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/getoai/getoai-cli/internal/installer"
+	"github.com/getoai/getoai-cli/internal/platform"
+	"github.com/getoai/getoai-cli/internal/util"
+)
+
+// RuntimeRequirement describes a runtime version an install method needs
+type RuntimeRequirement struct {
+	Runtime    string // "node", "python", "go", "docker" or "compose"
+	Constraint string // e.g. ">=18"
+	Found      string // installed version, empty if missing
+}
+
+// Satisfied reports whether the installed runtime meets the constraint
+func (r RuntimeRequirement) Satisfied() bool {
+	return platform.SatisfiesVersion(r.Found, r.Constraint)
+}
+
+// configFor returns the install config for method, honoring platform overrides
+func (t *Tool) configFor(p *platform.Platform, method installer.InstallMethod) (InstallConfig, bool) {
+	if config, ok := t.platformConfig(p, method); ok {
+		return config, true
+	}
+	config, ok := t.InstallMethods[method]
+	return config, ok
+}
+
+// RuntimeRequirements returns the runtime versions needed to install the
+// tool with method, along with the versions found on this system
+func (t *Tool) RuntimeRequirements(method installer.InstallMethod) []RuntimeRequirement {
+	p := platform.Detect()
+	config, ok := t.configFor(p, method)
+	if !ok {
+		return nil
+	}
+
+	// Docker version constraints don't apply to podman or nerdctl
+	dockerRuntime := installer.Runtime().Name() == "docker"
+
+	var reqs []RuntimeRequirement
+	for runtime, constraint := range config.Runtimes {
+		if !dockerRuntime && (runtime == "docker" || runtime == "compose") {
+			continue
+		}
+		reqs = append(reqs, RuntimeRequirement{
+			Runtime:    runtime,
+			Constraint: constraint,
+			Found:      p.RuntimeVersion(runtime),
+		})
+	}
+	sort.Slice(reqs, func(i, j int) bool {
+		return reqs[i].Runtime < reqs[j].Runtime
+	})
+	return reqs
+}
+
+// UnmetRuntimes returns the runtime requirements of method that are not satisfied
+func (t *Tool) UnmetRuntimes(method installer.InstallMethod) []RuntimeRequirement {
+	var unmet []RuntimeRequirement
+	for _, req := range t.RuntimeRequirements(method) {
+		if !req.Satisfied() {
+			unmet = append(unmet, req)
+		}
+	}
+	return unmet
+}
+
+// PreflightCheck is the result of comparing one resource requirement with
+// the measured value on this system
+type PreflightCheck struct {
+	Resource string // "memory", "disk" or "cpu"
+	Required string
+	Measured string
+	OK       bool
+	Fatal    bool // a failed fatal check blocks the install unless forced
+}
+
+// HasRequirements reports whether any resource requirement is declared
+func (r Resources) HasRequirements() bool {
+	return r.MemoryGB > 0 || r.DiskGB > 0 || r.CPUCores > 0
+}
+
+// String returns a summary like "16 GB RAM, 50 GB disk, 4 CPU cores (~9 GB download)"
+func (r Resources) String() string {
+	var parts []string
+	if r.MemoryGB > 0 {
+		parts = append(parts, fmt.Sprintf("%g GB RAM", r.MemoryGB))
+	}
+	if r.DiskGB > 0 {
+		parts = append(parts, fmt.Sprintf("%g GB disk", r.DiskGB))
+	}
+	if r.CPUCores > 0 {
+		parts = append(parts, fmt.Sprintf("%d CPU cores", r.CPUCores))
+	}
+	s := strings.Join(parts, ", ")
+	if r.DownloadGB > 0 {
+		s += fmt.Sprintf(" (~%g GB download)", r.DownloadGB)
+	}
+	return strings.TrimSpace(s)
+}
+
+// Preflight measures memory, free disk and CPU cores and compares them with
+// the tool's declared resources. Values that can't be measured are skipped.
+func (t *Tool) Preflight(method installer.InstallMethod) []PreflightCheck {
+	p := platform.Detect()
+	r := t.Resources
+	var checks []PreflightCheck
+
+	if r.MemoryGB > 0 {
+		if total := p.MemoryTotal(); total > 0 {
+			// The OS reports slightly less than the nominal RAM size
+			checks = append(checks, PreflightCheck{
+				Resource: "memory",
+				Required: fmt.Sprintf("%g GB", r.MemoryGB),
+				Measured: util.FormatBytes(total),
+				OK:       total >= gigabytes(r.MemoryGB*0.9),
+				Fatal:    true,
+			})
+		}
+	}
+
+	if r.DiskGB > 0 {
+		var free uint64
+		// Unknown when the container runtime doesn't say where it stores images
+		if dir := installLocation(p, method); dir != "" {
+			free = p.FreeDisk(dir)
+		}
+		if free > 0 {
+			checks = append(checks, PreflightCheck{
+				Resource: "disk",
+				Required: fmt.Sprintf("%g GB free", r.DiskGB),
+				Measured: util.FormatBytes(free) + " free",
+				OK:       free >= gigabytes(r.DiskGB),
+				Fatal:    true,
+			})
+		}
+	}
+
+	if r.CPUCores > 0 {
+		// Fewer cores only makes things slower, so this is just a warning
+		checks = append(checks, PreflightCheck{
+			Resource: "cpu",
+			Required: fmt.Sprintf("%d cores", r.CPUCores),
+			Measured: fmt.Sprintf("%d cores", p.CPUCores),
+			OK:       p.CPUCores >= r.CPUCores,
+		})
+	}
+
+	return checks
+}
+
+// installLocation returns the directory whose filesystem will hold the
+// tool's data for the given method, "" if it is unknown
+func installLocation(p *platform.Platform, method installer.InstallMethod) string {
+	if method == installer.MethodDocker && p.IsLinux() {
+		// Native Linux engines report their storage dir (/var/lib/docker,
+		// ~/.local/share/containers for rootless podman, ...)
+		return installer.Runtime().StorageDir()
+	}
+	// Docker Desktop keeps images inside a VM disk under the home directory
+	return filepath.Join(p.HomeDir, ".getoai")
+}
+
+func gigabytes(gb float64) uint64 {
+	return uint64(gb * 1024 * 1024 * 1024)
+}
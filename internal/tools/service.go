@@ -0,0 +1,109 @@
+package tools
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/getoai/getoai-cli/internal/installer"
+)
+
+// ServiceEnabled reports whether the tool's server runs as a systemd
+// user service
+func (t *Tool) ServiceEnabled() bool {
+	return t.Instance == "" && installer.UnitInstalled(t.Name)
+}
+
+// EnableService installs and starts a systemd user service running the
+// tool's server with its declared command and env, followed by args and
+// env from the user. A server started by getoai before is stopped first.
+func (t *Tool) EnableService(args []string, env map[string]string, restart string) error {
+	if t.Instance != "" {
+		return fmt.Errorf("named instances run in docker, which restarts them itself")
+	}
+	if t.ServiceKind() != ServiceNative {
+		if t.Service == nil {
+			return fmt.Errorf("%s does not run as a server", t.Name)
+		}
+		return fmt.Errorf("%s is not installed natively (%s not found)", t.Name, t.Service.Command[0])
+	}
+	if err := installer.CheckSystemd(); err != nil {
+		return err
+	}
+	binary, err := exec.LookPath(t.Service.Command[0])
+	if err != nil {
+		return fmt.Errorf("%s not found in PATH", t.Service.Command[0])
+	}
+	if abs, err := filepath.Abs(binary); err == nil {
+		binary = abs
+	}
+	unit, err := t.serviceUnit(binary, args, env, restart)
+	if err != nil {
+		return err
+	}
+
+	svc := t.nativeService()
+	if !svc.Managed() && svc.PID() != 0 {
+		if err := svc.Stop(); err != nil {
+			return err
+		}
+	} else if !svc.Managed() && svc.Running() {
+		return fmt.Errorf("port %d is in use by a %s not started by getoai, stop it first", svc.Port, t.Name)
+	}
+	return installer.InstallUnit(unit)
+}
+
+// serviceUnit returns the unit running the server with binary
+func (t *Tool) serviceUnit(binary string, args []string, env map[string]string, restart string) (installer.SystemdUnit, error) {
+	if t.Service.ArgsHint != "" && len(args) == 0 {
+		return installer.SystemdUnit{}, fmt.Errorf("%s needs %s, e.g. getoai service enable %s -- %s", t.Name, t.Service.ArgsHint, t.Name, t.Service.ArgsHint)
+	}
+	if restart == "" {
+		restart = t.Service.Restart
+	}
+	if restart != "" && !installer.ValidRestart(restart) {
+		return installer.SystemdUnit{}, fmt.Errorf("invalid restart policy %q, use one of: %s", restart, strings.Join(installer.RestartPolicies, ", "))
+	}
+
+	unitEnv := map[string]string{
+		// systemd starts services with a minimal PATH; keep the one the
+		// server was found with, for the tools it runs in turn
+		"PATH": os.Getenv("PATH"),
+	}
+	for k, v := range t.Service.Env {
+		unitEnv[k] = v
+	}
+	for k, v := range env {
+		unitEnv[k] = v
+	}
+
+	command := append([]string{binary}, t.Service.Command[1:]...)
+	return installer.SystemdUnit{
+		Name:        t.Name,
+		Description: fmt.Sprintf("%s server (getoai)", t.Name),
+		Command:     append(command, args...),
+		Env:         unitEnv,
+		Restart:     restart,
+		LogFile:     t.nativeService().LogFile(),
+	}, nil
+}
+
+// DisableService stops the tool's systemd user service and removes it.
+// The server can still be started with getoai start.
+func (t *Tool) DisableService() error {
+	if !t.ServiceEnabled() {
+		return fmt.Errorf("%s is not enabled as a service", t.Name)
+	}
+	return installer.RemoveUnit(t.Name)
+}
+
+// checkServiceArgs returns an error if the server can't be started by
+// getoai start because it needs arguments only a service unit can hold
+func (t *Tool) checkServiceArgs() error {
+	if t.Service.ArgsHint == "" || t.ServiceEnabled() {
+		return nil
+	}
+	return fmt.Errorf("%s needs %s, run it as a service with: getoai service enable %s -- %s", t.Name, t.Service.ArgsHint, t.Name, t.Service.ArgsHint)
+}
@@ -0,0 +1,45 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestServiceUnit(t *testing.T) {
+	tool := &Tool{
+		Name: "llama-cpp",
+		Service: &ServiceConfig{
+			Command:  []string{"llama-server", "--port", "8080"},
+			Env:      map[string]string{"LLAMA_ARG_THREADS": "4"},
+			Port:     8080,
+			ArgsHint: "-m <model.gguf>",
+		},
+	}
+
+	if _, err := tool.serviceUnit("/usr/bin/llama-server", nil, nil, ""); err == nil || !strings.Contains(err.Error(), "-m <model.gguf>") {
+		t.Errorf("serviceUnit() without the required args: error = %v", err)
+	}
+	if _, err := tool.serviceUnit("/usr/bin/llama-server", []string{"-m", "q.gguf"}, nil, "sometimes"); err == nil {
+		t.Error("serviceUnit() accepted an invalid restart policy")
+	}
+
+	t.Setenv("PATH", "/opt/bin:/usr/bin")
+	unit, err := tool.serviceUnit("/usr/bin/llama-server", []string{"-m", "q.gguf"}, map[string]string{"LLAMA_ARG_THREADS": "8"}, "always")
+	if err != nil {
+		t.Fatalf("serviceUnit() error = %v", err)
+	}
+	if got := strings.Join(unit.Command, " "); got != "/usr/bin/llama-server --port 8080 -m q.gguf" {
+		t.Errorf("Command = %s", got)
+	}
+	if unit.Env["LLAMA_ARG_THREADS"] != "8" || unit.Env["PATH"] != "/opt/bin:/usr/bin" {
+		t.Errorf("Env = %v, want the user's value over the declared one and PATH", unit.Env)
+	}
+	if unit.Restart != "always" || unit.Name != "llama-cpp" {
+		t.Errorf("unit = %+v", unit)
+	}
+
+	tool.Service.Restart = "on-abnormal"
+	if unit, _ := tool.serviceUnit("/usr/bin/llama-server", []string{"-m", "q.gguf"}, nil, ""); unit.Restart != "on-abnormal" {
+		t.Errorf("Restart = %q, want the declared policy", unit.Restart)
+	}
+}
@@ -0,0 +1,93 @@
+package tools
+
+import "strings"
+
+// StackPrefix marks a stack where a tool name is expected, e.g.
+// `getoai install stack/local-chat`
+const StackPrefix = "stack/"
+
+// Stack is a curated set of tools that are installed and managed together.
+// Its members share the getoai network, so UIs find the model servers of
+// the stack through their BackendEnv.
+type Stack struct {
+	Name        string
+	Description string
+	Tools       []StackTool // in install order
+	Notes       string      // shown after install, e.g. steps left to the user
+}
+
+// StackTool is a member of a stack
+type StackTool struct {
+	Name string
+
+	// Env vars set on the member's container, in addition to its own
+	// BackendEnv; {name} stands for the base URL of another member
+	Env map[string]string
+}
+
+var stacks = []*Stack{
+	{
+		Name:        "local-chat",
+		Description: "Chat with local models: Ollama with Open WebUI",
+		Tools: []StackTool{
+			{Name: "ollama"},
+			{Name: "open-webui"},
+		},
+		Notes: "Pull a model with 'ollama pull llama3.2', then open the chat with 'getoai open open-webui'",
+	},
+	{
+		Name:        "api-chat",
+		Description: "One API gateway for all your providers, with LobeChat as the UI",
+		Tools: []StackTool{
+			{Name: "one-api"},
+			{Name: "lobechat", Env: map[string]string{"OPENAI_PROXY_URL": "{one-api}/v1"}},
+		},
+		Notes: "Add your provider keys in One API (default login root / 123456) and create a token, then run\n" +
+			"'getoai install lobechat --env OPENAI_API_KEY=<token>'",
+	},
+	{
+		Name:        "rag",
+		Description: "Chat with your documents: Ollama with AnythingLLM",
+		Tools: []StackTool{
+			{Name: "ollama"},
+			{Name: "anythingllm"},
+		},
+		Notes: "Pull a chat and an embedding model with 'ollama pull llama3.2' and 'ollama pull nomic-embed-text'",
+	},
+}
+
+// GetStack returns the stack with the given name, with or without the
+// stack/ prefix
+func GetStack(name string) (*Stack, bool) {
+	name = strings.TrimPrefix(name, StackPrefix)
+	for _, s := range stacks {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+// ListStacks returns all stacks
+func ListStacks() []*Stack {
+	return stacks
+}
+
+// ToolNames returns the names of the stack's tools in install order
+func (s *Stack) ToolNames() []string {
+	names := make([]string, len(s.Tools))
+	for i, st := range s.Tools {
+		names[i] = st.Name
+	}
+	return names
+}
+
+// Member returns the stack's settings for a tool, if it is a member
+func (s *Stack) Member(name string) (StackTool, bool) {
+	for _, st := range s.Tools {
+		if st.Name == name {
+			return st, true
+		}
+	}
+	return StackTool{}, false
+}
@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/getoai/getoai-cli/internal/installer"
+)
+
+func TestGetStack(t *testing.T) {
+	for _, name := range []string{"local-chat", "stack/local-chat"} {
+		if s, ok := GetStack(name); !ok || s.Name != "local-chat" {
+			t.Errorf("GetStack(%q) = %v, %v", name, s, ok)
+		}
+	}
+	if _, ok := GetStack("stack/unknown"); ok {
+		t.Error("GetStack(stack/unknown) found a stack")
+	}
+}
+
+func TestStacksAreValid(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, stack := range ListStacks() {
+		if seen[stack.Name] {
+			t.Errorf("duplicate stack %s", stack.Name)
+		}
+		seen[stack.Name] = true
+		if len(stack.Tools) < 2 || stack.Description == "" {
+			t.Errorf("%s: a stack needs a description and at least two tools", stack.Name)
+		}
+
+		earlier := make(map[string]bool)
+		for _, member := range stack.Tools {
+			tool, ok := Get(member.Name)
+			if !ok {
+				t.Errorf("%s: unknown tool %s", stack.Name, member.Name)
+				continue
+			}
+			if len(member.Env) > 0 && tool.ContainerName() == "" {
+				t.Errorf("%s: Env set on %s, which doesn't run as a container", stack.Name, member.Name)
+			}
+			for key, template := range member.Env {
+				if !installer.ValidEnvKey(key) {
+					t.Errorf("%s: invalid variable %s on %s", stack.Name, key, member.Name)
+				}
+				for _, ref := range backendRefs(template) {
+					if !earlier[ref] {
+						t.Errorf("%s: %s on %s refers to %s, which isn't installed before it", stack.Name, key, member.Name, ref)
+					}
+				}
+			}
+			earlier[member.Name] = true
+		}
+	}
+}
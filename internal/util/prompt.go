@@ -38,3 +38,21 @@ func PromptChoice(title string, options []string, descriptions []string) (int, e
 
 	return choice - 1, nil
 }
+
+// Confirm asks a yes/no question. An empty answer selects the default.
+func Confirm(question string, defaultYes bool) bool {
+	hint := "[y/N]"
+	if defaultYes {
+		hint = "[Y/n]"
+	}
+	fmt.Printf("%s %s ", question, hint)
+
+	reader := bufio.NewReader(os.Stdin)
+	response, _ := reader.ReadString('\n')
+	response = strings.TrimSpace(strings.ToLower(response))
+
+	if response == "" {
+		return defaultYes
+	}
+	return response == "y" || response == "yes"
+}